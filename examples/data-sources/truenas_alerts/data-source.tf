@@ -0,0 +1,10 @@
+data "truenas_alerts" "critical" {
+  min_level = "CRITICAL"
+
+  lifecycle {
+    postcondition {
+      condition     = length(self.alerts) == 0
+      error_message = "TrueNAS has critical alerts: ${join(", ", self.alerts[*].message)}"
+    }
+  }
+}
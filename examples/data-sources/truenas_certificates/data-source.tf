@@ -0,0 +1,5 @@
+data "truenas_certificates" "all" {}
+
+output "expiring_certificates" {
+  value = [for c in data.truenas_certificates.all.certificates : c.name if timecmp(c.expires_at, timeadd(plantimestamp(), "720h")) < 0]
+}
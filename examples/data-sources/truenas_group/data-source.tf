@@ -0,0 +1,3 @@
+data "truenas_group" "builtin_users" {
+  name = "builtin_users"
+}
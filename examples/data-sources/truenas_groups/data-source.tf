@@ -0,0 +1,3 @@
+data "truenas_groups" "custom" {
+  builtin = false
+}
@@ -0,0 +1 @@
+data "truenas_nfs_config" "nfs" {}
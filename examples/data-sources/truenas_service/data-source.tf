@@ -1,3 +1,6 @@
 data "truenas_service" "svc" {
   service_id = 3
-}
\ No newline at end of file
+}
+data "truenas_service" "ssh" {
+  name = "ssh"
+}
@@ -0,0 +1 @@
+data "truenas_smb_config" "smb" {}
@@ -0,0 +1 @@
+data "truenas_ssh_config" "ssh" {}
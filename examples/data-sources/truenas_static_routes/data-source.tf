@@ -0,0 +1 @@
+data "truenas_static_routes" "routes" {}
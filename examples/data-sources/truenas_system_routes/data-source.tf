@@ -0,0 +1,3 @@
+data "truenas_system_routes" "routes" {
+  interface = "eth0"
+}
@@ -0,0 +1,3 @@
+data "truenas_user" "www" {
+  name = "www"
+}
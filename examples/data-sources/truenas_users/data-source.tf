@@ -0,0 +1,4 @@
+data "truenas_users" "smb" {
+  builtin = false
+  smb     = true
+}
@@ -0,0 +1 @@
+data "truenas_vm_bootloader_options" "all" {}
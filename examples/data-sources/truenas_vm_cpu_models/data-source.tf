@@ -0,0 +1 @@
+data "truenas_vm_cpu_models" "all" {}
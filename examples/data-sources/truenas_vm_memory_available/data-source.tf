@@ -0,0 +1,3 @@
+data "truenas_vm_memory_available" "current" {
+  overcommit = false
+}
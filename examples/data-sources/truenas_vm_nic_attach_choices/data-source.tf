@@ -0,0 +1 @@
+data "truenas_vm_nic_attach_choices" "all" {}
@@ -0,0 +1,3 @@
+data "truenas_vm_pci_devices" "gpus" {
+  available_only = true
+}
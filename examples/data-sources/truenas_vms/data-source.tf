@@ -0,0 +1,4 @@
+data "truenas_vms" "running" {
+  name_regex = "^k8s"
+  state      = "RUNNING"
+}
@@ -0,0 +1,4 @@
+terraform import truenas_acme_dns_authenticator.default {{id}}
+
+# Example:
+terraform import truenas_acme_dns_authenticator.default 1
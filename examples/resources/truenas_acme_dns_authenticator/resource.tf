@@ -0,0 +1,8 @@
+resource "truenas_acme_dns_authenticator" "cloudflare" {
+  name          = "cloudflare"
+  authenticator = "cloudflare"
+
+  attributes = {
+    api_token = var.cloudflare_api_token
+  }
+}
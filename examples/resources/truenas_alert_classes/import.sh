@@ -0,0 +1,2 @@
+# Alert classes configuration is a singleton, any ID can be used
+terraform import truenas_alert_classes.default alert-classes
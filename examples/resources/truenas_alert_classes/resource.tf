@@ -0,0 +1,13 @@
+resource "truenas_alert_classes" "classes" {
+  class {
+    name   = "ScrubPaused"
+    level  = "INFO"
+    policy = "DAILY"
+  }
+
+  class {
+    name   = "SMART"
+    level  = "CRITICAL"
+    policy = "IMMEDIATELY"
+  }
+}
@@ -0,0 +1,4 @@
+terraform import truenas_alert_service.default {{id}}
+
+# Example:
+terraform import truenas_alert_service.default 1
@@ -0,0 +1,19 @@
+resource "truenas_alert_service" "email" {
+  name          = "Email admins"
+  level         = "WARNING"
+  test_on_apply = true
+
+  mail {
+    email = "admins@example.com"
+  }
+}
+
+resource "truenas_alert_service" "pagerduty" {
+  name  = "PagerDuty"
+  level = "CRITICAL"
+
+  pagerduty {
+    service_key = var.pagerduty_integration_key
+    client_name = "truenas"
+  }
+}
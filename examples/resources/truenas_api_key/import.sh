@@ -0,0 +1,4 @@
+terraform import truenas_api_key.default {{id}}
+
+# Example:
+terraform import truenas_api_key.default 1
@@ -0,0 +1,14 @@
+resource "truenas_api_key" "ci" {
+  name     = "ci"
+  username = "svc_ci"
+
+  expires_at = "2026-01-01T00:00:00Z"
+
+  # change to generate new key
+  reset_trigger = "2024-06"
+}
+
+output "ci_api_key" {
+  value     = truenas_api_key.ci.key
+  sensitive = true
+}
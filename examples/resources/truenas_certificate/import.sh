@@ -0,0 +1,4 @@
+terraform import truenas_certificate.default {{id}}
+
+# Example:
+terraform import truenas_certificate.default 1
@@ -0,0 +1,48 @@
+# Certificate signed by internal certificate authority
+resource "truenas_certificate" "internal" {
+  name         = "nas-internal"
+  type         = "INTERNAL"
+  signed_by    = truenas_certificate_authority.internal.id
+  common       = "nas.example.com"
+  country      = "US"
+  state        = "California"
+  city         = "San Jose"
+  organization = "Example"
+  email        = "admin@example.com"
+  san          = ["nas.example.com", "192.168.1.10"]
+  lifetime     = 397
+}
+
+# Imported certificate
+resource "truenas_certificate" "imported" {
+  name        = "nas-imported"
+  type        = "IMPORTED"
+  certificate = file("nas.example.com.crt")
+  private_key = file("nas.example.com.key")
+}
+
+# Let's Encrypt certificate
+resource "truenas_certificate" "csr" {
+  name         = "nas-csr"
+  type         = "CSR"
+  common       = "nas.example.com"
+  country      = "US"
+  state        = "California"
+  city         = "San Jose"
+  organization = "Example"
+  email        = "admin@example.com"
+  san          = ["nas.example.com"]
+}
+
+resource "truenas_certificate" "acme" {
+  name               = "nas-letsencrypt"
+  type               = "ACME"
+  csr_id             = truenas_certificate.csr.id
+  acme_directory_uri = "https://acme-v02.api.letsencrypt.org/directory"
+  tos                = true
+  renew_days         = 10
+
+  dns_mapping = {
+    "nas.example.com" = truenas_acme_dns_authenticator.cloudflare.id
+  }
+}
@@ -0,0 +1,4 @@
+terraform import truenas_certificate_authority.default {{id}}
+
+# Example:
+terraform import truenas_certificate_authority.default 1
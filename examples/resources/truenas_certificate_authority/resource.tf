@@ -0,0 +1,14 @@
+resource "truenas_certificate_authority" "internal" {
+  name         = "internal-ca"
+  type         = "INTERNAL"
+  key_type     = "EC"
+  ec_curve     = "SECP384R1"
+  lifetime     = 3650
+  common       = "Example Internal CA"
+  country      = "US"
+  state        = "California"
+  city         = "San Jose"
+  organization = "Example"
+  email        = "admin@example.com"
+  san          = ["ca.example.com"]
+}
@@ -0,0 +1,4 @@
+terraform import truenas_cloud_credential.default {{id}}
+
+# Example:
+terraform import truenas_cloud_credential.default 1
@@ -0,0 +1,9 @@
+resource "truenas_cloud_credential" "b2" {
+  name   = "backblaze"
+  verify = true
+
+  b2 {
+    account = var.b2_key_id
+    key     = var.b2_application_key
+  }
+}
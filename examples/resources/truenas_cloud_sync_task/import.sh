@@ -0,0 +1,4 @@
+terraform import truenas_cloud_sync_task.default {{id}}
+
+# Example:
+terraform import truenas_cloud_sync_task.default 1
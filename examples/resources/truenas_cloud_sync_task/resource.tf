@@ -0,0 +1,31 @@
+resource "truenas_cloud_sync_task" "offsite" {
+  description   = "Offsite backup"
+  direction     = "PUSH"
+  transfer_mode = "SYNC"
+  path          = "/mnt/tank/data"
+  credentials   = truenas_cloud_credential.b2.id
+  bucket        = "truenas-backup"
+  folder        = "data"
+  snapshot      = true
+
+  encryption          = true
+  filename_encryption = true
+  encryption_password = var.backup_password
+  encryption_salt     = var.backup_salt
+
+  bwlimit {
+    time      = "08:00"
+    bandwidth = 1048576
+  }
+
+  bwlimit {
+    time = "18:00"
+  }
+
+  exclude = ["*.tmp", ".cache/**"]
+
+  schedule {
+    minute = "0"
+    hour   = "2"
+  }
+}
@@ -0,0 +1,4 @@
+terraform import truenas_group_members.default {{group_id}}
+
+# Example:
+terraform import truenas_group_members.default "42"
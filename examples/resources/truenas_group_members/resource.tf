@@ -0,0 +1,7 @@
+resource "truenas_group_members" "media" {
+  group_id = truenas_group.media.id
+  user_ids = [
+    truenas_user.plex.id,
+    truenas_user.jellyfin.id,
+  ]
+}
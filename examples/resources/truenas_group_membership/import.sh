@@ -0,0 +1,4 @@
+terraform import truenas_group_membership.default {{group_id}}/{{user_id}},{{user_id}}
+
+# Example:
+terraform import truenas_group_membership.default "42/43,44"
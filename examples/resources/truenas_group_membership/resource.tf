@@ -0,0 +1,8 @@
+data "truenas_group" "builtin_users" {
+  name = "builtin_users"
+}
+
+resource "truenas_group_membership" "ci" {
+  group_id = data.truenas_group.builtin_users.group_id
+  user_ids = [truenas_user.ci.id]
+}
@@ -0,0 +1,4 @@
+terraform import truenas_init_script.default {{id}}
+
+# Example:
+terraform import truenas_init_script.default 1
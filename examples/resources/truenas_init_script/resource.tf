@@ -0,0 +1,11 @@
+resource "truenas_init_script" "disable_apm" {
+  command = "hdparm -B 255 /dev/sda"
+  when    = "POSTINIT"
+  timeout = 30
+  comment = "Disable disk power management"
+}
+
+resource "truenas_init_script" "shutdown" {
+  script = "/mnt/tank/scripts/shutdown.sh"
+  when   = "SHUTDOWN"
+}
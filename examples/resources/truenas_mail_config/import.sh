@@ -0,0 +1,2 @@
+# Mail configuration is a singleton, any ID can be used
+terraform import truenas_mail_config.mail mail-config
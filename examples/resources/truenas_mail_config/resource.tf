@@ -0,0 +1,24 @@
+resource "truenas_mail_config" "mail" {
+  fromemail      = "nas@example.com"
+  fromname       = "TrueNAS"
+  outgoingserver = "smtp.example.com"
+  port           = 587
+  security       = "TLS"
+  smtp           = true
+  user           = "nas@example.com"
+  pass           = var.smtp_password
+
+  send_test_email_to = "admin@example.com"
+}
+
+# Gmail with OAuth, tokens are obtained with TrueNAS web interface
+resource "truenas_mail_config" "gmail" {
+  fromemail = "nas@gmail.com"
+
+  oauth {
+    provider      = "gmail"
+    client_id     = var.oauth_client_id
+    client_secret = var.oauth_client_secret
+    refresh_token = var.oauth_refresh_token
+  }
+}
@@ -0,0 +1,2 @@
+# Network configuration is a singleton, any ID can be used
+terraform import truenas_network_configuration.network network-configuration
@@ -0,0 +1,18 @@
+resource "truenas_network_configuration" "network" {
+  hostname    = "nas01"
+  domain      = "example.com"
+  domains     = ["corp.example.com"]
+  ipv4gateway = "192.168.1.1"
+  nameserver1 = "192.168.1.2"
+  nameserver2 = "1.1.1.1"
+
+  hosts = [
+    "192.168.1.20 backup.example.com backup",
+  ]
+
+  service_announcement {
+    mdns    = true
+    netbios = false
+    wsd     = true
+  }
+}
@@ -0,0 +1,4 @@
+terraform import truenas_network_interface.default {{name}}
+
+# Example:
+terraform import truenas_network_interface.default br0
@@ -0,0 +1,27 @@
+resource "truenas_network_interface" "bond0" {
+  name         = "bond0"
+  type         = "LINK_AGGREGATION"
+  lag_protocol = "LACP"
+  lag_ports    = ["eno1", "eno2"]
+  mtu          = 9000
+}
+
+resource "truenas_network_interface" "storage" {
+  name                  = "vlan20"
+  type                  = "VLAN"
+  description           = "Storage network"
+  vlan_parent_interface = truenas_network_interface.bond0.name
+  vlan_tag              = 20
+
+  alias {
+    address = "10.20.0.10"
+    netmask = 24
+  }
+}
+
+resource "truenas_network_interface" "vms" {
+  name           = "br0"
+  type           = "BRIDGE"
+  bridge_members = [truenas_network_interface.bond0.name]
+  ipv4_dhcp      = true
+}
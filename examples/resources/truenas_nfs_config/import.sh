@@ -0,0 +1,2 @@
+# NFS configuration is a singleton, any ID can be used
+terraform import truenas_nfs_config.nfs nfs-config
@@ -0,0 +1,10 @@
+resource "truenas_nfs_config" "nfs" {
+  servers           = 8
+  protocols         = ["NFSV3", "NFSV4"]
+  v4_domain         = "example.com"
+  bindip            = ["192.168.1.10"]
+  mountd_port       = 618
+  rpcstatd_port     = 871
+  rpclockd_port     = 32803
+  userd_manage_gids = true
+}
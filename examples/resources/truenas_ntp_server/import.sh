@@ -0,0 +1,4 @@
+terraform import truenas_ntp_server.default {{id}}
+
+# Example:
+terraform import truenas_ntp_server.default 1
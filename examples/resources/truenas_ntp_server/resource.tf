@@ -0,0 +1,7 @@
+resource "truenas_ntp_server" "pool" {
+  address = "0.pool.ntp.org"
+  iburst  = true
+  prefer  = true
+  minpoll = 6
+  maxpoll = 10
+}
@@ -0,0 +1,4 @@
+terraform import truenas_rsync_module.default {{id}}
+
+# Example:
+terraform import truenas_rsync_module.default 1
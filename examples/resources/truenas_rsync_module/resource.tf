@@ -0,0 +1,9 @@
+resource "truenas_rsync_module" "backup" {
+  name        = "backup"
+  comment     = "Backup target for Linux hosts"
+  path        = "/mnt/tank/backup"
+  mode        = "RW"
+  user        = "backup"
+  group       = "backup"
+  hosts_allow = ["192.168.1.0/24"]
+}
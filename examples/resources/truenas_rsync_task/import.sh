@@ -0,0 +1,4 @@
+terraform import truenas_rsync_task.default {{id}}
+
+# Example:
+terraform import truenas_rsync_task.default 1
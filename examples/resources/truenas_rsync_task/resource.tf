@@ -0,0 +1,17 @@
+resource "truenas_rsync_task" "backup" {
+  path        = "/mnt/tank/data"
+  user        = "root"
+  mode        = "SSH"
+  remote_host = "backup@192.168.1.50"
+  remote_path = "/srv/backup/truenas"
+  direction   = "PUSH"
+  description = "Nightly push to backup host"
+  archive     = true
+  delete      = true
+  extra       = ["--bwlimit=5000"]
+
+  schedule {
+    minute = "30"
+    hour   = "1"
+  }
+}
@@ -0,0 +1,4 @@
+terraform import truenas_service.default {{name}}
+
+# Example:
+terraform import truenas_service.default cifs
@@ -0,0 +1,10 @@
+resource "truenas_service" "cifs" {
+  name   = "cifs"
+  enable = true
+  state  = "RUNNING"
+
+  # reload SMB service whenever global SMB configuration changes
+  reload_triggers = {
+    smb_config = jsonencode(truenas_smb_config.smb)
+  }
+}
@@ -0,0 +1,4 @@
+terraform import truenas_share_smb_acl.default {{share_name}}
+
+# Example:
+terraform import truenas_share_smb_acl.default projects
@@ -0,0 +1,19 @@
+resource "truenas_share_smb_acl" "projects" {
+  share_name = truenas_share_smb.projects.name
+
+  entry {
+    ae_who_name = "AD\\Domain Admins"
+    ae_perm     = "FULL"
+  }
+
+  entry {
+    ae_who_name = "AD\\Domain Users"
+    ae_perm     = "CHANGE"
+  }
+
+  entry {
+    ae_who_sid = "S-1-5-32-546" # Guests
+    ae_perm    = "READ"
+    ae_type    = "DENIED"
+  }
+}
@@ -0,0 +1,2 @@
+# SMB configuration is a singleton, any ID can be used
+terraform import truenas_smb_config.smb smb-config
@@ -0,0 +1,11 @@
+resource "truenas_smb_config" "smb" {
+  netbiosname     = "nas01"
+  workgroup       = "EXAMPLE"
+  description     = "File server"
+  enable_smb1     = false
+  aapl_extensions = true
+  filemask        = "0664"
+  dirmask         = "0775"
+  admin_group     = "smb_admins"
+  bindip          = ["192.168.1.10"]
+}
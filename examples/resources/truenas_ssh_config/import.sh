@@ -0,0 +1,2 @@
+# SSH configuration is a singleton, any ID can be used
+terraform import truenas_ssh_config.ssh ssh-config
@@ -0,0 +1,12 @@
+resource "truenas_ssh_config" "ssh" {
+  tcpport      = 22
+  rootlogin    = false
+  passwordauth = false
+  tcpfwd       = false
+  weak_ciphers = []
+}
+
+# known_hosts entries for the NAS
+output "known_hosts" {
+  value = [for key in values(truenas_ssh_config.ssh.host_public_keys) : "nas.example.com ${key}"]
+}
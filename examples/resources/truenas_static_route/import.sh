@@ -0,0 +1,4 @@
+terraform import truenas_static_route.default {{id}}
+
+# Example:
+terraform import truenas_static_route.default 1
@@ -0,0 +1,5 @@
+resource "truenas_static_route" "vpn" {
+  destination = "10.20.0.0/16"
+  gateway     = "192.168.1.254"
+  description = "Site-to-site VPN"
+}
@@ -0,0 +1,2 @@
+# System general configuration is a singleton, any ID can be used
+terraform import truenas_system_general.default system-general
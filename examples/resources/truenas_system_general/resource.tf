@@ -0,0 +1,9 @@
+resource "truenas_system_general" "general" {
+  ui_certificate    = truenas_certificate.ui.id
+  ui_httpsredirect  = true
+  ui_httpsprotocols = ["TLSv1.2", "TLSv1.3"]
+  timezone          = "America/Los_Angeles"
+  language          = "en"
+  kbdmap            = "us"
+  usage_collection  = false
+}
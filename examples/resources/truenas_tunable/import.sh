@@ -0,0 +1,4 @@
+terraform import truenas_tunable.default {{id}}
+
+# Example:
+terraform import truenas_tunable.default 1
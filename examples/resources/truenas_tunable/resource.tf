@@ -0,0 +1,6 @@
+resource "truenas_tunable" "somaxconn" {
+  type    = "SYSCTL"
+  var     = "net.core.somaxconn"
+  value   = "4096"
+  comment = "Larger listen backlog"
+}
@@ -12,5 +12,20 @@ resource "truenas_user" "tu" {
   sudo_nopasswd     = false
   password_disabled = true
   shell             = "/usr/local/bin/bash"
-  ssh_public_key    = "ssh-rsa ... jane.doe@example.com"
+
+  ssh_public_keys = [
+    "ssh-ed25519 AAAA... jane.doe@laptop",
+    "ssh-ed25519 AAAA... jane.doe@desktop",
+  ]
+
+  two_factor_enabled = true
+}
+
+# Password is only stored in state as a hash, increment password_version to rotate
+resource "truenas_user" "svc" {
+  full_name         = "Backup service"
+  create_group      = true
+  name              = "svc_backup"
+  generate_password = true
+  password_version  = 1
 }
@@ -0,0 +1,265 @@
+package truenas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The SDK does not cover every middleware endpoint, helpers below call the REST API directly
+// reusing SDK client configuration (base URL, authenticated HTTP client and debug flag)
+
+// apiError is returned for any non 2xx API response
+type apiError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       []byte
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s\n%s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// isNotFound returns true if err is an API 404 response
+func isNotFound(err error) bool {
+	if apiErr, ok := err.(*apiError); ok {
+		return apiErr.StatusCode == http.StatusNotFound
+	}
+
+	return false
+}
+
+func callAPI(ctx context.Context, m interface{}, method string, path string, query url.Values, in interface{}, out interface{}) error {
+	cfg := m.(*providerMeta).GetConfig()
+
+	baseURL, err := cfg.ServerURLWithContext(ctx, "")
+
+	if err != nil {
+		return err
+	}
+
+	u := strings.TrimSuffix(baseURL, "/") + path
+
+	if len(query) > 0 {
+		u = u + "?" + query.Encode()
+	}
+
+	var body *bytes.Buffer
+
+	if in != nil {
+		payload, err := json.Marshal(in)
+
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewBuffer(payload)
+	} else {
+		body = &bytes.Buffer{}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if cfg.UserAgent != "" {
+		req.Header.Set("User-Agent", cfg.UserAgent)
+	}
+
+	if cfg.Debug {
+		dump, err := httputil.DumpRequestOut(req, true)
+		if err != nil {
+			return err
+		}
+		log.Printf("\n%s\n", string(dump))
+	}
+
+	resp, err := cfg.HTTPClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return err
+	}
+
+	if cfg.Debug {
+		log.Printf("\n%s %s: %d\n%s\n", method, path, resp.StatusCode, string(respBody))
+	}
+
+	if resp.StatusCode >= 300 {
+		return &apiError{
+			Method:     method,
+			Path:       path,
+			StatusCode: resp.StatusCode,
+			Body:       respBody,
+		}
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("error decoding %s %s response: %s", method, path, err)
+	}
+
+	return nil
+}
+
+func apiGet(ctx context.Context, m interface{}, path string, query url.Values, out interface{}) error {
+	return callAPI(ctx, m, http.MethodGet, path, query, nil, out)
+}
+
+func apiPost(ctx context.Context, m interface{}, path string, in interface{}, out interface{}) error {
+	return callAPI(ctx, m, http.MethodPost, path, nil, in, out)
+}
+
+func apiPut(ctx context.Context, m interface{}, path string, in interface{}, out interface{}) error {
+	return callAPI(ctx, m, http.MethodPut, path, nil, in, out)
+}
+
+func apiDelete(ctx context.Context, m interface{}, path string, in interface{}) error {
+	return callAPI(ctx, m, http.MethodDelete, path, nil, in, nil)
+}
+
+// apiDate is middleware datetime, serialized as {"$date": <milliseconds since epoch>}
+type apiDate struct {
+	Date int64 `json:"$date"`
+}
+
+func newAPIDate(t time.Time) *apiDate {
+	return &apiDate{Date: t.UnixMilli()}
+}
+
+// Time returns datetime in UTC
+func (d *apiDate) Time() time.Time {
+	return time.UnixMilli(d.Date).UTC()
+}
+
+// flattenAPIDate returns RFC 3339 datetime, empty string for nil
+func flattenAPIDate(d *apiDate) string {
+	if d == nil {
+		return ""
+	}
+
+	return d.Time().Format(time.RFC3339)
+}
+
+// apiJob is core.get_jobs entry
+type apiJob struct {
+	Id     int             `json:"id"`
+	Method string          `json:"method"`
+	State  string          `json:"state"`
+	Error  *string         `json:"error"`
+	Result json.RawMessage `json:"result"`
+}
+
+const apiJobPollInterval = 2 * time.Second
+
+// callAPIJob calls endpoint that may be a middleware job, when response is a job ID it waits for the job
+// and decodes job result into out. Non job responses are decoded directly.
+func callAPIJob(ctx context.Context, m interface{}, method string, path string, in interface{}, out interface{}) error {
+	var resp json.RawMessage
+
+	if err := callAPI(ctx, m, method, path, nil, in, &resp); err != nil {
+		return err
+	}
+
+	var jobID int
+
+	if err := json.Unmarshal(resp, &jobID); err != nil {
+		if out == nil || len(resp) == 0 {
+			return nil
+		}
+		return json.Unmarshal(resp, out)
+	}
+
+	result, err := waitForJob(ctx, m, jobID)
+
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(result) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(result, out)
+}
+
+func apiPostJob(ctx context.Context, m interface{}, path string, in interface{}, out interface{}) error {
+	return callAPIJob(ctx, m, http.MethodPost, path, in, out)
+}
+
+func apiPutJob(ctx context.Context, m interface{}, path string, in interface{}, out interface{}) error {
+	return callAPIJob(ctx, m, http.MethodPut, path, in, out)
+}
+
+func apiDeleteJob(ctx context.Context, m interface{}, path string, in interface{}) error {
+	return callAPIJob(ctx, m, http.MethodDelete, path, in, nil)
+}
+
+// waitForJob polls job until it finishes, returns job result
+func waitForJob(ctx context.Context, m interface{}, id int) (json.RawMessage, error) {
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(id))
+
+	for {
+		var jobs []apiJob
+
+		if err := apiGet(ctx, m, "/core/get_jobs", query, &jobs); err != nil {
+			return nil, fmt.Errorf("error getting job %d: %s", id, err)
+		}
+
+		if len(jobs) == 0 {
+			return nil, fmt.Errorf("job %d not found", id)
+		}
+
+		job := jobs[0]
+
+		switch job.State {
+		case "SUCCESS":
+			return job.Result, nil
+		case "FAILED", "ABORTED":
+			message := job.State
+
+			if job.Error != nil {
+				message = *job.Error
+			}
+
+			return nil, fmt.Errorf("job %d (%s) failed: %s", id, job.Method, message)
+		}
+
+		log.Printf("[DEBUG] Waiting for TrueNAS job %d (%s): %s", id, job.Method, job.State)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timeout waiting for job %d (%s): %s", id, job.Method, ctx.Err())
+		case <-time.After(apiJobPollInterval):
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package truenas
+
+import (
+	"context"
+	"encoding/json"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIDate(t *testing.T) {
+	var d apiDate
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"$date": 1672531200000}`), &d))
+	assert.Equal(t, "2023-01-01T00:00:00Z", flattenAPIDate(&d))
+	assert.Equal(t, "", flattenAPIDate(nil))
+
+	payload, err := json.Marshal(newAPIDate(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"$date": 1672531200000}`, string(payload))
+}
+
+func TestAPIPostJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/job":
+			w.Write([]byte(`42`))
+		case "/plain":
+			w.Write([]byte(`true`))
+		case "/core/get_jobs":
+			assert.Equal(t, "42", r.URL.Query().Get("id"))
+			w.Write([]byte(`[{"id": 42, "method": "service.start", "state": "SUCCESS", "result": true}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := api.NewConfiguration()
+	config.Servers = api.ServerConfigurations{{URL: server.URL}}
+	client := newProviderMeta(api.NewAPIClient(config))
+
+	ctx := context.Background()
+
+	var result bool
+
+	assert.NoError(t, apiPostJob(ctx, client, "/job", nil, &result))
+	assert.True(t, result)
+
+	result = false
+
+	assert.NoError(t, apiPostJob(ctx, client, "/plain", nil, &result))
+	assert.True(t, result)
+
+	err := apiPostJob(ctx, client, "/missing", nil, nil)
+	assert.True(t, isNotFound(err))
+}
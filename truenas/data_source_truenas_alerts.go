@@ -0,0 +1,145 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strconv"
+	"time"
+)
+
+// Alert is alert.list entry
+type Alert struct {
+	UUID      string   `json:"uuid"`
+	Source    string   `json:"source"`
+	Klass     string   `json:"klass"`
+	Level     string   `json:"level"`
+	Formatted *string  `json:"formatted"`
+	Datetime  *apiDate `json:"datetime"`
+	Dismissed bool     `json:"dismissed"`
+}
+
+func dataSourceTrueNASAlerts() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get current alerts, optionally filtered by level. Use in preconditions to fail pipelines when TrueNAS is unhealthy.",
+		ReadContext: dataSourceTrueNASAlertsRead,
+		Schema: map[string]*schema.Schema{
+			"min_level": &schema.Schema{
+				Description:  "Only return alerts of this level or higher: `INFO`, `NOTICE`, `WARNING`, `ERROR`, `CRITICAL`, `ALERT` or `EMERGENCY`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(alertLevels, false),
+			},
+			"include_dismissed": &schema.Schema{
+				Description: "Include dismissed alerts",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"alerts": &schema.Schema{
+				Description: "Matching alerts",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uuid": &schema.Schema{
+							Description: "Alert ID",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"class": &schema.Schema{
+							Description: "Alert class name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"source": &schema.Schema{
+							Description: "Alert source",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"level": &schema.Schema{
+							Description: "Alert level",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"message": &schema.Schema{
+							Description: "Formatted alert message",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"datetime": &schema.Schema{
+							Description: "Alert time in RFC 3339 format",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"dismissed": &schema.Schema{
+							Description: "`true` if alert is dismissed",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASAlertsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var alerts []Alert
+
+	if err := apiGet(ctx, m, "/alert/list", nil, &alerts); err != nil {
+		return diag.Errorf("error getting alerts: %s", err)
+	}
+
+	minLevel := alertLevelIndex(d.Get("min_level").(string))
+	includeDismissed := d.Get("include_dismissed").(bool)
+
+	res := make([]interface{}, 0, len(alerts))
+
+	for _, alert := range alerts {
+		if alert.Dismissed && !includeDismissed || alertLevelIndex(alert.Level) < minLevel {
+			continue
+		}
+
+		item := map[string]interface{}{
+			"uuid":      alert.UUID,
+			"class":     alert.Klass,
+			"source":    alert.Source,
+			"level":     alert.Level,
+			"dismissed": alert.Dismissed,
+		}
+
+		if alert.Formatted != nil {
+			item["message"] = *alert.Formatted
+		}
+
+		if alert.Datetime != nil {
+			item["datetime"] = flattenAPIDate(alert.Datetime)
+		}
+
+		res = append(res, item)
+	}
+
+	if err := d.Set("alerts", res); err != nil {
+		return diag.Errorf("error setting alerts: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// alertLevelIndex returns severity of level, 0 for empty or unknown level
+func alertLevelIndex(level string) int {
+	for i, l := range alertLevels {
+		if l == level {
+			return i
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,29 @@
+package truenas
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceTruenasAlerts_basic(t *testing.T) {
+	resourceName := "data.truenas_alerts.alerts"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// alerts depend on system state, check only that the list is readable
+				Config: `
+					data "truenas_alerts" "alerts" {
+						min_level = "EMERGENCY"
+						include_dismissed = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "alerts.#"),
+				),
+			},
+		},
+	})
+}
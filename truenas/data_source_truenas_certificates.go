@@ -0,0 +1,143 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+func dataSourceTrueNASCertificates() *schema.Resource {
+	certificate := certificateDetailsSchema()
+
+	certificate["certificate_id"] = &schema.Schema{
+		Description: "Certificate ID",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	certificate["name"] = &schema.Schema{
+		Description: "Certificate name",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	certificate["type"] = &schema.Schema{
+		Description: "Certificate type: `IMPORTED`, `CSR`, `INTERNAL` or `ACME`",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	certificate["common"] = &schema.Schema{
+		Description: "Subject common name",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	certificate["certificate"] = &schema.Schema{
+		Description: "Certificate in PEM format",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	certificate["revoked"] = &schema.Schema{
+		Description: "`true` if certificate is revoked",
+		Type:        schema.TypeBool,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Get list of certificates, optionally filtered. Certificate signing requests are not included.",
+		ReadContext: dataSourceTrueNASCertificatesRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": &schema.Schema{
+				Description:  "Only return certificates with name matching this regular expression",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"ids": &schema.Schema{
+				Description: "IDs of matching certificates",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"certificates": &schema.Schema{
+				Description: "Matching certificates",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: certificate,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASCertificatesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var certificates []Certificate
+
+	if err := apiGet(ctx, m, "/certificate", nil, &certificates); err != nil {
+		return diag.Errorf("error getting certificates: %s", err)
+	}
+
+	var nameRegex *regexp.Regexp
+
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	ids := make([]interface{}, 0, len(certificates))
+	res := make([]interface{}, 0, len(certificates))
+
+	for _, certificate := range certificates {
+		if certificate.Certificate == nil || *certificate.Certificate == "" {
+			continue
+		}
+
+		if nameRegex != nil && !nameRegex.MatchString(certificate.Name) {
+			continue
+		}
+
+		item := map[string]interface{}{
+			"certificate_id": strconv.Itoa(certificate.Id),
+			"name":           certificate.Name,
+			"type":           flattenCertificateType(certificate),
+			"certificate":    *certificate.Certificate,
+			"revoked":        certificate.Revoked,
+		}
+
+		if certificate.Common != nil {
+			item["common"] = *certificate.Common
+		}
+
+		details, err := parseCertificateDetails(*certificate.Certificate)
+
+		if err != nil {
+			log.Printf("[WARN] Unable to parse TrueNAS certificate (%d): %s", certificate.Id, err)
+		}
+
+		for k, v := range details {
+			item[k] = v
+		}
+
+		ids = append(ids, strconv.Itoa(certificate.Id))
+		res = append(res, item)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.Errorf("error setting certificate ids: %s", err)
+	}
+
+	if err := d.Set("certificates", res); err != nil {
+		return diag.Errorf("error setting certificates: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
@@ -84,7 +84,7 @@ func dataSourceTrueNASCronjob() *schema.Resource {
 func dataSourceTrueNASCronjobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id, err := strconv.Atoi(d.Get("cronjob_id").(string))
 
 	if err != nil {
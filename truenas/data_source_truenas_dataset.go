@@ -459,7 +459,7 @@ func updateDatasetResourceFromResponse(resp *api.Dataset, d *schema.ResourceData
 func dataSourceTrueNASDatasetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id := d.Get("dataset_id").(string)
 
 	resp, _, err := c.DatasetApi.GetDataset(ctx, id).Execute()
@@ -0,0 +1,206 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"sort"
+	"strconv"
+)
+
+func dataSourceTrueNASGroup() *schema.Resource {
+	s := groupDataSourceSchema()
+
+	lookup := []string{"group_id", "name", "gid"}
+
+	s["group_id"] = &schema.Schema{
+		Description:  "Group ID (TrueNAS internal ID, not GID)",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: lookup,
+	}
+
+	s["name"] = &schema.Schema{
+		Description:  "Group name",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: lookup,
+	}
+
+	s["gid"] = &schema.Schema{
+		Description:  "Group GID",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: lookup,
+	}
+
+	return &schema.Resource{
+		Description: "Get information about a group by ID, name or GID",
+		ReadContext: dataSourceTrueNASGroupRead,
+		Schema:      s,
+	}
+}
+
+// groupDataSourceSchema returns computed group attributes shared by truenas_group and truenas_groups data sources
+func groupDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"group_id": &schema.Schema{
+			Description: "Group ID (TrueNAS internal ID, not GID)",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"name": &schema.Schema{
+			Description: "Group name",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"gid": &schema.Schema{
+			Description: "Group GID",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"builtin": &schema.Schema{
+			Description: "`true` if group is built-in system group",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"local": &schema.Schema{
+			Description: "`true` if group is local (not from directory service)",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"smb": &schema.Schema{
+			Description: "`true` if group is mapped into an NT group",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"sudo": &schema.Schema{
+			Description: "`true` if group members may invoke sudo",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"sudo_commands": &schema.Schema{
+			Description: "Executables group members may invoke via sudo without a password",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"sudo_no_password": &schema.Schema{
+			Description: "`true` if group members may invoke sudo without a password",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"user_ids": &schema.Schema{
+			Description: "IDs of member users (TrueNAS internal IDs, same as `truenas_user` resource ID)",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+		"users": &schema.Schema{
+			Description: "Usernames of member users",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cache := getIdentityCache(m)
+
+	var group *APIGroup
+	var err error
+
+	if id, ok := d.GetOk("group_id"); ok {
+		group, err = cache.GroupByID(ctx, m, id.(int))
+	} else if name, ok := d.GetOk("name"); ok {
+		group, err = cache.GroupByName(ctx, m, name.(string))
+	} else if gid, ok := d.GetOkExists("gid"); ok {
+		// gid 0 is valid (wheel)
+		group, err = cache.GroupByGID(ctx, m, gid.(int))
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if group == nil {
+		return diag.Errorf("error getting group: not found")
+	}
+
+	usernames, diagErr := enumerateUsernames(ctx, m)
+
+	if diagErr != nil {
+		return *diagErr
+	}
+
+	for key, value := range flattenGroup(*group, usernames) {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting group %s: %s", key, err)
+		}
+	}
+
+	d.SetId(strconv.Itoa(group.Id))
+
+	return diags
+}
+
+// enumerateUsernames returns map of TrueNAS user ID to username
+func enumerateUsernames(ctx context.Context, m interface{}) (map[int]string, *diag.Diagnostics) {
+	users, err := getIdentityCache(m).Users(ctx, m)
+
+	if err != nil {
+		diags := diag.FromErr(err)
+		return nil, &diags
+	}
+
+	usernames := make(map[int]string, len(users))
+
+	for _, u := range users {
+		usernames[int(u.Id)] = u.Username
+	}
+
+	return usernames, nil
+}
+
+// flattenGroup returns group attributes shared by truenas_group and truenas_groups data sources,
+// usernames is used to translate member user IDs to usernames
+func flattenGroup(group APIGroup, usernames map[int]string) map[string]interface{} {
+	userIDs := make([]interface{}, 0, len(group.Users))
+	users := make([]string, 0, len(group.Users))
+
+	for _, id := range group.Users {
+		userIDs = append(userIDs, id)
+
+		if username, ok := usernames[id]; ok {
+			users = append(users, username)
+		}
+	}
+
+	sort.Strings(users)
+
+	return map[string]interface{}{
+		"group_id":         group.Id,
+		"name":             group.Group,
+		"gid":              group.Gid,
+		"builtin":          group.Builtin,
+		"local":            group.Local,
+		"smb":              group.Smb,
+		"sudo":             group.Sudo,
+		"sudo_commands":    flattenStringList(group.SudoCommands),
+		"sudo_no_password": group.SudoNopasswd,
+		"user_ids":         userIDs,
+		"users":            flattenStringList(users),
+	}
+}
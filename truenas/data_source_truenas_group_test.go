@@ -0,0 +1,43 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceTruenasGroup_basic(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	name := fmt.Sprintf("%s-%s", testResourcePrefix, suffix)
+	resourceName := "data.truenas_group.group"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourceTruenasGroupConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "builtin", "false"),
+					resource.TestCheckResourceAttr(resourceName, "sudo", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "gid", "truenas_group.group", "gid"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceTruenasGroupConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "truenas_group" "group" {
+			name = "%s"
+			sudo = true
+		}
+
+		data "truenas_group" "group" {
+			gid = truenas_group.group.gid
+		}
+	`, name)
+}
@@ -0,0 +1,102 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"time"
+)
+
+func dataSourceTrueNASGroups() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get list of groups, optionally filtered",
+		ReadContext: dataSourceTrueNASGroupsRead,
+		Schema: map[string]*schema.Schema{
+			"builtin": &schema.Schema{
+				Description: "Only return built-in (`true`) or user created (`false`) groups",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"local": &schema.Schema{
+				Description: "Only return local (`true`) or directory service (`false`) groups",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"smb": &schema.Schema{
+				Description: "Only return groups with matching SMB setting",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"ids": &schema.Schema{
+				Description: "IDs of matching groups",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"groups": &schema.Schema{
+				Description: "Matching groups, same attributes as `truenas_group` data source",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: groupDataSourceSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	resp, diagErr := enumerateGroups(ctx, m)
+
+	if diagErr != nil {
+		return *diagErr
+	}
+
+	usernames, diagErr := enumerateUsernames(ctx, m)
+
+	if diagErr != nil {
+		return *diagErr
+	}
+
+	builtin, filterBuiltin := d.GetOkExists("builtin")
+	local, filterLocal := d.GetOkExists("local")
+	smb, filterSmb := d.GetOkExists("smb")
+
+	ids := make([]interface{}, 0, len(resp))
+	groups := make([]interface{}, 0, len(resp))
+
+	for _, g := range resp {
+		if filterBuiltin && g.Builtin != builtin.(bool) {
+			continue
+		}
+
+		if filterLocal && g.Local != local.(bool) {
+			continue
+		}
+
+		if filterSmb && g.Smb != smb.(bool) {
+			continue
+		}
+
+		ids = append(ids, g.Id)
+		groups = append(groups, flattenGroup(g, usernames))
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.Errorf("error setting group ids: %s", err)
+	}
+
+	if err := d.Set("groups", groups); err != nil {
+		return diag.Errorf("error setting groups: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
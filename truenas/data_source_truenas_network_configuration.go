@@ -95,7 +95,7 @@ func dataSourceTrueNASNetworkConfiguration() *schema.Resource {
 func dataSourceTrueNASNetworkConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	config, _, err := c.NetworkApi.GetNetworkConfiguration(ctx).Execute()
 
@@ -0,0 +1,27 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTrueNASNFSConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get global NFS service configuration",
+		ReadContext: dataSourceTrueNASNFSConfigRead,
+		Schema:      computedSchema(nfsConfigSchema()),
+	}
+}
+
+func dataSourceTrueNASNFSConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := resourceTrueNASNFSConfigRead(ctx, d, m)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(nfsConfigID)
+
+	return diags
+}
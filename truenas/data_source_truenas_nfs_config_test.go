@@ -0,0 +1,24 @@
+package truenas
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceTruenasNFSConfig_basic(t *testing.T) {
+	resourceName := "data.truenas_nfs_config.nfs"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "truenas_nfs_config" "nfs" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "servers"),
+					resource.TestCheckResourceAttrSet(resourceName, "protocols.#"),
+				),
+			},
+		},
+	})
+}
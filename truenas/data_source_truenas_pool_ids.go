@@ -23,7 +23,7 @@ func dataSourceTrueNASPoolIDs() *schema.Resource {
 }
 
 func dataSourceTrueNASPoolsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -15,14 +15,18 @@ func dataSourceTrueNASService() *schema.Resource {
 		ReadContext: dataSourceTrueNASServiceRead,
 		Schema: map[string]*schema.Schema{
 			"service_id": &schema.Schema{
-				Description: "Service ID",
-				Type:        schema.TypeInt,
-				Required:    true,
+				Description:  "Service ID",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"service_id", "name"},
 			},
 			"name": &schema.Schema{
-				Description: "Service name",
-				Type:        schema.TypeString,
-				Computed:    true,
+				Description:  "Service name, eg. `cifs`, `nfs`, `ssh`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"service_id", "name"},
 			},
 			"enabled": &schema.Schema{
 				Description: "`true` if service is enabled",
@@ -49,19 +53,39 @@ func dataSourceTrueNASService() *schema.Resource {
 func dataSourceTrueNASServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
-	id := d.Get("service_id").(int)
+	c := m.(*providerMeta)
+
+	var resp *api.Service
+
+	if name, ok := d.GetOk("name"); ok {
+		service, err := getServiceByName(ctx, m, name.(string))
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if service == nil {
+			return diag.Errorf("service %s not found", name)
+		}
 
-	resp, _, err := c.ServiceApi.GetService(ctx, int32(id)).Execute()
+		resp = service
+	} else {
+		id := d.Get("service_id").(int)
 
-	if err != nil {
-		var body []byte
-		if apiErr, ok := err.(*api.GenericOpenAPIError); ok {
-			body = apiErr.Body()
+		service, _, err := c.ServiceApi.GetService(ctx, int32(id)).Execute()
+
+		if err != nil {
+			var body []byte
+			if apiErr, ok := err.(*api.GenericOpenAPIError); ok {
+				body = apiErr.Body()
+			}
+			return diag.Errorf("error getting service: %s\n%s", err, body)
 		}
-		return diag.Errorf("error getting service: %s\n%s", err, body)
+
+		resp = service
 	}
 
+	d.Set("service_id", int(resp.Id))
 	d.Set("name", resp.Service)
 	d.Set("enabled", *resp.Enable)
 
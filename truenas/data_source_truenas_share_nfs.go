@@ -108,7 +108,7 @@ func dataSourceTrueNASShareNFS() *schema.Resource {
 func dataSourceTrueNASShareNFSRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id := d.Get("sharenfs_id").(int)
 
 	resp, _, err := c.SharingApi.GetShareNFS(ctx, int32(id)).Execute()
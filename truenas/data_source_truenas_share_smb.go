@@ -151,7 +151,7 @@ func dataSourceTrueNASShareSMB() *schema.Resource {
 func dataSourceTrueNASShareSMBRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id := d.Get("sharesmb_id").(int)
 
 	resp, _, err := c.SharingApi.GetShareSMB(ctx, int32(id)).Execute()
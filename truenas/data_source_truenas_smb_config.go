@@ -0,0 +1,27 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTrueNASSMBConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get global SMB service configuration",
+		ReadContext: dataSourceTrueNASSMBConfigRead,
+		Schema:      computedSchema(smbConfigSchema()),
+	}
+}
+
+func dataSourceTrueNASSMBConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := resourceTrueNASSMBConfigRead(ctx, d, m)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(smbConfigID)
+
+	return diags
+}
@@ -0,0 +1,24 @@
+package truenas
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceTruenasSMBConfig_basic(t *testing.T) {
+	resourceName := "data.truenas_smb_config.smb"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "truenas_smb_config" "smb" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "netbiosname"),
+					resource.TestCheckResourceAttrSet(resourceName, "workgroup"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,27 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTrueNASSSHConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get global SSH service configuration",
+		ReadContext: dataSourceTrueNASSSHConfigRead,
+		Schema:      computedSchema(sshConfigSchema()),
+	}
+}
+
+func dataSourceTrueNASSSHConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := resourceTrueNASSSHConfigRead(ctx, d, m)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(sshConfigID)
+
+	return diags
+}
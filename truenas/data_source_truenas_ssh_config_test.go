@@ -0,0 +1,24 @@
+package truenas
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceTruenasSSHConfig_basic(t *testing.T) {
+	resourceName := "data.truenas_ssh_config.ssh"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "truenas_ssh_config" "ssh" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "tcpport"),
+					resource.TestCheckResourceAttrSet(resourceName, "host_key_fingerprints.%"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"time"
+)
+
+func dataSourceTrueNASStaticRoutes() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get configured static routes",
+		ReadContext: dataSourceTrueNASStaticRoutesRead,
+		Schema: map[string]*schema.Schema{
+			"ids": &schema.Schema{
+				Description: "Static route IDs",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"routes": &schema.Schema{
+				Description: "Static routes",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"route_id": &schema.Schema{
+							Description: "Static route ID",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"destination": &schema.Schema{
+							Description: "Destination network in CIDR notation",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"gateway": &schema.Schema{
+							Description: "Gateway IP address",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": &schema.Schema{
+							Description: "Route description",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASStaticRoutesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var routes []StaticRoute
+
+	if err := apiGet(ctx, m, "/staticroute", nil, &routes); err != nil {
+		return diag.Errorf("error getting static routes: %s", err)
+	}
+
+	ids := make([]interface{}, len(routes))
+	res := make([]interface{}, len(routes))
+
+	for i, route := range routes {
+		ids[i] = strconv.Itoa(route.Id)
+		res[i] = map[string]interface{}{
+			"route_id":    strconv.Itoa(route.Id),
+			"destination": route.Destination,
+			"gateway":     route.Gateway,
+			"description": route.Description,
+		}
+	}
+
+	d.Set("ids", ids)
+
+	if err := d.Set("routes", res); err != nil {
+		return diag.Errorf("error setting routes: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
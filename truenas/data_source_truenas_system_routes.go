@@ -0,0 +1,135 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"time"
+)
+
+// SystemRoute is route.system_routes entry
+type SystemRoute struct {
+	Network         string   `json:"network"`
+	Netmask         string   `json:"netmask"`
+	Gateway         *string  `json:"gateway"`
+	Interface       string   `json:"interface"`
+	Flags           []string `json:"flags"`
+	TableID         int      `json:"table_id"`
+	Scope           int      `json:"scope"`
+	PreferredSource *string  `json:"preferred_source"`
+}
+
+func dataSourceTrueNASSystemRoutes() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get live system routing table, including routes from interfaces, DHCP and static routes",
+		ReadContext: dataSourceTrueNASSystemRoutesRead,
+		Schema: map[string]*schema.Schema{
+			"interface": &schema.Schema{
+				Description: "Only return routes using this interface",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"routes": &schema.Schema{
+				Description: "Routes",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network": &schema.Schema{
+							Description: "Destination network address",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"netmask": &schema.Schema{
+							Description: "Destination netmask",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"gateway": &schema.Schema{
+							Description: "Gateway address, empty for directly connected networks",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"interface": &schema.Schema{
+							Description: "Interface name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"flags": &schema.Schema{
+							Description: "Route flags",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"table_id": &schema.Schema{
+							Description: "Routing table ID",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"scope": &schema.Schema{
+							Description: "Route scope",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"preferred_source": &schema.Schema{
+							Description: "Preferred source address",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASSystemRoutesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var routes []SystemRoute
+
+	if err := apiGet(ctx, m, "/route/system_routes", nil, &routes); err != nil {
+		return diag.Errorf("error getting system routes: %s", err)
+	}
+
+	iface := d.Get("interface").(string)
+
+	res := []interface{}{}
+
+	for _, route := range routes {
+		if iface != "" && route.Interface != iface {
+			continue
+		}
+
+		r := map[string]interface{}{
+			"network":   route.Network,
+			"netmask":   route.Netmask,
+			"interface": route.Interface,
+			"flags":     flattenStringList(route.Flags),
+			"table_id":  route.TableID,
+			"scope":     route.Scope,
+		}
+
+		if route.Gateway != nil {
+			r["gateway"] = *route.Gateway
+		}
+
+		if route.PreferredSource != nil {
+			r["preferred_source"] = *route.PreferredSource
+		}
+
+		res = append(res, r)
+	}
+
+	if err := d.Set("routes", res); err != nil {
+		return diag.Errorf("error setting routes: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
@@ -0,0 +1,310 @@
+package truenas
+
+import (
+	"context"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"strings"
+)
+
+func dataSourceTrueNASUser() *schema.Resource {
+	s := userDataSourceSchema()
+
+	lookup := []string{"user_id", "name", "uid"}
+
+	s["user_id"] = &schema.Schema{
+		Description:  "User ID (TrueNAS internal ID, not UID)",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: lookup,
+	}
+
+	s["name"] = &schema.Schema{
+		Description:  "Username",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: lookup,
+	}
+
+	s["uid"] = &schema.Schema{
+		Description:  "User UID",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: lookup,
+	}
+
+	return &schema.Resource{
+		Description: "Get information about a user by ID, username or UID",
+		ReadContext: dataSourceTrueNASUserRead,
+		Schema:      s,
+	}
+}
+
+// userDataSourceSchema returns computed user attributes shared by truenas_user and truenas_users data sources
+func userDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"user_id": &schema.Schema{
+			Description: "User ID (TrueNAS internal ID, not UID)",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"name": &schema.Schema{
+			Description: "Username",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"uid": &schema.Schema{
+			Description: "User UID",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"full_name": &schema.Schema{
+			Description: "User full name",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"email": &schema.Schema{
+			Description: "User email address",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"gid": &schema.Schema{
+			Description: "Primary group GID",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"group_ids": &schema.Schema{
+			Description: "GIDs of supplementary groups the user belongs to",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+		"home_directory": &schema.Schema{
+			Description: "Home directory path",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"shell": &schema.Schema{
+			Description: "User shell",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"builtin": &schema.Schema{
+			Description: "`true` if user is built-in system account",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"local": &schema.Schema{
+			Description: "`true` if user is local (not from directory service)",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"locked": &schema.Schema{
+			Description: "`true` if account is locked",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"microsoft_account": &schema.Schema{
+			Description: "`true` if account is used for Microsoft authentication",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"password_disabled": &schema.Schema{
+			Description: "`true` if account can only use SSH keys for authentication",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"smb": &schema.Schema{
+			Description: "`true` if user is mapped into an NT login account",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"ssh_public_key": &schema.Schema{
+			Description: "SSH public key(s)",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"ssh_public_keys": &schema.Schema{
+			Description: "SSH public keys, one per authorized_keys line",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"two_factor_enabled": &schema.Schema{
+			Description: "`true` if user has two-factor authentication secret configured (TrueNAS SCALE 24.04 or newer)",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"sudo": &schema.Schema{
+			Description: "`true` if user may invoke sudo",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"sudo_commands": &schema.Schema{
+			Description: "Executables the user may invoke via sudo without a password",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"sudo_no_password": &schema.Schema{
+			Description: "`true` if user may invoke sudo without a password",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+}
+
+func dataSourceTrueNASUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cache := getIdentityCache(m)
+
+	var user *api.User
+	var err error
+
+	if id, ok := d.GetOk("user_id"); ok {
+		user, err = cache.UserByID(ctx, m, id.(int))
+	} else if name, ok := d.GetOk("name"); ok {
+		user, err = cache.UserByName(ctx, m, name.(string))
+	} else if uid, ok := d.GetOkExists("uid"); ok {
+		// uid 0 is valid (root)
+		user, err = cache.UserByUID(ctx, m, uid.(int))
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if user == nil {
+		return diag.Errorf("error getting user: not found")
+	}
+
+	gids, diagErr := enumerateGroupGIDs(ctx, m)
+
+	if diagErr != nil {
+		return *diagErr
+	}
+
+	for key, value := range flattenUser(*user, gids) {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting user %s: %s", key, err)
+		}
+	}
+
+	d.SetId(strconv.Itoa(int(user.Id)))
+
+	return diags
+}
+
+// enumerateGroupGIDs returns map of TrueNAS group ID to group GID
+func enumerateGroupGIDs(ctx context.Context, m interface{}) (map[int]int, *diag.Diagnostics) {
+	groups, diags := enumerateGroups(ctx, m)
+
+	if diags != nil {
+		return nil, diags
+	}
+
+	gids := make(map[int]int, len(groups))
+
+	for _, g := range groups {
+		gids[g.Id] = g.Gid
+	}
+
+	return gids, nil
+}
+
+// flattenUser returns user attributes shared by truenas_user and truenas_users data sources,
+// gids is used to translate TrueNAS group IDs to GIDs
+func flattenUser(user api.User, gids map[int]int) map[string]interface{} {
+	res := map[string]interface{}{
+		"user_id":   int(user.Id),
+		"name":      user.Username,
+		"full_name": user.FullName,
+	}
+
+	if user.Uid != nil {
+		res["uid"] = int(*user.Uid)
+	}
+
+	if user.Email.IsSet() && user.Email.Get() != nil {
+		res["email"] = *user.Email.Get()
+	}
+
+	if user.Group != nil && user.Group.BsdgrpGid != nil {
+		res["gid"] = int(*user.Group.BsdgrpGid)
+	}
+
+	groups := make([]interface{}, 0, len(user.Groups))
+
+	for _, id := range user.Groups {
+		if gid, ok := gids[int(id)]; ok {
+			groups = append(groups, gid)
+		}
+	}
+
+	res["group_ids"] = groups
+
+	if user.Home != nil {
+		res["home_directory"] = *user.Home
+	}
+
+	if user.Shell != nil {
+		res["shell"] = *user.Shell
+	}
+
+	if user.Builtin != nil {
+		res["builtin"] = *user.Builtin
+	}
+
+	if user.Local != nil {
+		res["local"] = *user.Local
+	}
+
+	if user.Locked != nil {
+		res["locked"] = *user.Locked
+	}
+
+	if user.MicrosoftAccount != nil {
+		res["microsoft_account"] = *user.MicrosoftAccount
+	}
+
+	if user.PasswordDisabled != nil {
+		res["password_disabled"] = *user.PasswordDisabled
+	}
+
+	if user.Smb != nil {
+		res["smb"] = *user.Smb
+	}
+
+	if user.Sshpubkey.IsSet() && user.Sshpubkey.Get() != nil {
+		res["ssh_public_key"] = strings.TrimSpace(*user.Sshpubkey.Get())
+		res["ssh_public_keys"] = flattenSSHPublicKeys(nil, *user.Sshpubkey.Get())
+	}
+
+	if configured, ok := user.AdditionalProperties["twofactor_auth_configured"].(bool); ok {
+		res["two_factor_enabled"] = configured
+	}
+
+	if user.Sudo != nil {
+		res["sudo"] = *user.Sudo
+	}
+
+	res["sudo_commands"] = flattenStringList(user.SudoCommands)
+
+	if user.SudoNopasswd != nil {
+		res["sudo_no_password"] = *user.SudoNopasswd
+	}
+
+	return res
+}
@@ -0,0 +1,29 @@
+package truenas
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceTruenasUser_basic(t *testing.T) {
+	resourceName := "data.truenas_user.root"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "truenas_user" "root" {
+						uid = 0
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "root"),
+					resource.TestCheckResourceAttr(resourceName, "builtin", "true"),
+					resource.TestCheckResourceAttr(resourceName, "gid", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,129 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"time"
+)
+
+func dataSourceTrueNASUsers() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get list of users, optionally filtered",
+		ReadContext: dataSourceTrueNASUsersRead,
+		Schema: map[string]*schema.Schema{
+			"builtin": &schema.Schema{
+				Description: "Only return built-in (`true`) or user created (`false`) accounts",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"local": &schema.Schema{
+				Description: "Only return local (`true`) or directory service (`false`) accounts",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"smb": &schema.Schema{
+				Description: "Only return users with matching SMB setting",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"gid": &schema.Schema{
+				Description: "Only return members (primary or supplementary) of group with this GID",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"ids": &schema.Schema{
+				Description: "IDs of matching users",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"users": &schema.Schema{
+				Description: "Matching users, same attributes as `truenas_user` data source",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: userDataSourceSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	resp, err := getIdentityCache(m).Users(ctx, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gids, diagErr := enumerateGroupGIDs(ctx, m)
+
+	if diagErr != nil {
+		return *diagErr
+	}
+
+	builtin, filterBuiltin := d.GetOkExists("builtin")
+	local, filterLocal := d.GetOkExists("local")
+	smb, filterSmb := d.GetOkExists("smb")
+	gid, filterGid := d.GetOkExists("gid")
+
+	ids := make([]interface{}, 0, len(resp))
+	users := make([]interface{}, 0, len(resp))
+
+	for _, u := range resp {
+		if filterBuiltin && (u.Builtin == nil || *u.Builtin != builtin.(bool)) {
+			continue
+		}
+
+		if filterLocal && (u.Local == nil || *u.Local != local.(bool)) {
+			continue
+		}
+
+		if filterSmb && (u.Smb == nil || *u.Smb != smb.(bool)) {
+			continue
+		}
+
+		user := flattenUser(u, gids)
+
+		if filterGid && !isUserGroupMember(user, gid.(int)) {
+			continue
+		}
+
+		ids = append(ids, int(u.Id))
+		users = append(users, user)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.Errorf("error setting user ids: %s", err)
+	}
+
+	if err := d.Set("users", users); err != nil {
+		return diag.Errorf("error setting users: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// isUserGroupMember checks primary and supplementary groups of flattened user
+func isUserGroupMember(user map[string]interface{}, gid int) bool {
+	if primary, ok := user["gid"]; ok && primary.(int) == gid {
+		return true
+	}
+
+	for _, g := range user["group_ids"].([]interface{}) {
+		if g.(int) == gid {
+			return true
+		}
+	}
+
+	return false
+}
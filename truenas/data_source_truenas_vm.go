@@ -10,116 +10,174 @@ import (
 )
 
 func dataSourceTrueNASVM() *schema.Resource {
+	s := vmDataSourceSchema()
+
+	s["vm_id"] = &schema.Schema{
+		Description: "VM ID",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+
 	return &schema.Resource{
 		ReadContext: dataSourceTrueNASVMRead,
-		Schema: map[string]*schema.Schema{
-			"vm_id": &schema.Schema{
-				Description: "VM ID",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"name": &schema.Schema{
-				Description: "VM name",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			"description": &schema.Schema{
-				Description: "VM description",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			"bootloader": &schema.Schema{
-				Description: "VM bootloader",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			"vcpus": &schema.Schema{
-				Description: "Number of virtual CPUs",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			"cores": &schema.Schema{
-				Description: "Number of CPU cores",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			"threads": &schema.Schema{
-				Description: "Number of CPU threads",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			"shutdown_timeout": &schema.Schema{
-				Description: "Shutdown timeout in seconds",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			"memory": &schema.Schema{
-				Description: "Total memory available for VM (bytes)",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			"autostart": &schema.Schema{
-				Description: "`true` if VM is set to autostart",
-				Type:        schema.TypeBool,
-				Computed:    true,
-			},
-			"time": &schema.Schema{
-				Description: "VM system time. Default is `Local`",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
-			"device": &schema.Schema{
-				Type:     schema.TypeSet,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": &schema.Schema{
-							Description: "Device ID",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"type": &schema.Schema{
-							Description: "Device type",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"order": &schema.Schema{
-							Description: "Device order",
-							Type:        schema.TypeInt,
-							Computed:    true,
-						},
-						"vm": &schema.Schema{
-							Description: "Device VM ID",
-							Type:        schema.TypeInt,
-							Computed:    true,
-						},
-						"attributes": &schema.Schema{
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Computed: true,
+		Schema:      s,
+	}
+}
+
+// vmDataSourceSchema returns computed VM attributes shared by truenas_vm and truenas_vms data sources
+func vmDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Description: "VM name",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"description": &schema.Schema{
+			Description: "VM description",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"bootloader": &schema.Schema{
+			Description: "VM bootloader",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"vcpus": &schema.Schema{
+			Description: "Number of virtual CPUs",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"cores": &schema.Schema{
+			Description: "Number of CPU cores",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"threads": &schema.Schema{
+			Description: "Number of CPU threads",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"shutdown_timeout": &schema.Schema{
+			Description: "Shutdown timeout in seconds",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"memory": &schema.Schema{
+			Description: "Total memory available for VM (bytes)",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"min_memory": &schema.Schema{
+			Description: "Minimum memory the VM can be ballooned down to (bytes)",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"cpu_mode": &schema.Schema{
+			Description: "CPU mode",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"cpu_model": &schema.Schema{
+			Description: "Emulated CPU model",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"cpuset": &schema.Schema{
+			Description: "Host CPUs the VM vCPUs are pinned to",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"nodeset": &schema.Schema{
+			Description: "Host NUMA nodes the VM memory is allocated from",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"hide_from_msr": &schema.Schema{
+			Description: "`true` if KVM hypervisor is hidden from MSR based discovery",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"ensure_display_device": &schema.Schema{
+			Description: "`true` if guest always has access to a video device",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"hyperv_enlightenments": &schema.Schema{
+			Description: "`true` if Hyper-V enlightenments are enabled",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"arch_type": &schema.Schema{
+			Description: "Guest architecture type",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"machine_type": &schema.Schema{
+			Description: "Guest machine type",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"autostart": &schema.Schema{
+			Description: "`true` if VM is set to autostart",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"time": &schema.Schema{
+			Description: "VM system time. Default is `Local`",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"device": &schema.Schema{
+			Type:     schema.TypeSet,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": &schema.Schema{
+						Description: "Device ID",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"type": &schema.Schema{
+						Description: "Device type",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"order": &schema.Schema{
+						Description: "Device order",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"vm": &schema.Schema{
+						Description: "Device VM ID",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"attributes": &schema.Schema{
+						Type: schema.TypeMap,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
 						},
+						Computed: true,
 					},
 				},
 			},
-			"status": &schema.Schema{
-				Type:     schema.TypeSet,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"state": &schema.Schema{
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"pid": &schema.Schema{
-							Type:     schema.TypeInt,
-							Computed: true,
-						},
-						"domain_state": &schema.Schema{
-							Type:     schema.TypeString,
-							Computed: true,
-						},
+		},
+		"status": &schema.Schema{
+			Type:     schema.TypeSet,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"state": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"pid": &schema.Schema{
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"domain_state": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
 					},
 				},
 			},
@@ -130,7 +188,7 @@ func dataSourceTrueNASVM() *schema.Resource {
 func dataSourceTrueNASVMRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id, err := strconv.Atoi(d.Get("vm_id").(string))
 
 	if err != nil {
@@ -147,59 +205,73 @@ func dataSourceTrueNASVMRead(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.Errorf("error getting VM: %s\n%s", err, body)
 	}
 
-	d.Set("name", resp.Name)
+	for key, value := range flattenVM(*resp) {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("error setting VM %s: %s", key, err)
+		}
+	}
+
+	d.SetId(strconv.Itoa(int(resp.Id)))
+
+	return diags
+}
+
+// flattenVM returns VM attributes shared by truenas_vm and truenas_vms data sources
+func flattenVM(vm api.VM) map[string]interface{} {
+	res := map[string]interface{}{
+		"vm_id": strconv.Itoa(int(vm.Id)),
+		"name":  vm.Name,
+	}
 
-	if resp.Bootloader != nil {
-		d.Set("bootloader", *resp.Bootloader)
+	if vm.Bootloader != nil {
+		res["bootloader"] = *vm.Bootloader
 	}
 
-	if resp.Description != nil {
-		d.Set("description", *resp.Description)
+	if vm.Description != nil {
+		res["description"] = *vm.Description
 	}
 
-	if resp.Vcpus != nil {
-		d.Set("vcpus", *resp.Vcpus)
+	if vm.Vcpus != nil {
+		res["vcpus"] = *vm.Vcpus
 	}
 
-	if resp.Cores != nil {
-		d.Set("cores", *resp.Cores)
+	if vm.Cores != nil {
+		res["cores"] = *vm.Cores
 	}
 
-	if resp.Threads != nil {
-		d.Set("threads", *resp.Threads)
+	if vm.Threads != nil {
+		res["threads"] = *vm.Threads
 	}
 
-	if resp.Memory != nil {
-		d.Set("memory", *resp.Memory)
+	if vm.Memory != nil {
+		res["memory"] = *vm.Memory
 	}
 
-	if resp.Autostart != nil {
-		d.Set("autostart", *resp.Autostart)
+	if vm.Autostart != nil {
+		res["autostart"] = *vm.Autostart
 	}
 
-	if resp.ShutdownTimeout != nil {
-		d.Set("shutdown_timeout", *resp.ShutdownTimeout)
+	if vm.ShutdownTimeout != nil {
+		res["shutdown_timeout"] = *vm.ShutdownTimeout
 	}
 
-	if resp.Time != nil {
-		d.Set("time", *resp.Time)
+	if vm.Time != nil {
+		res["time"] = *vm.Time
 	}
 
-	if resp.Devices != nil {
-		if err := d.Set("device", flattenVMDevices(resp.Devices)); err != nil {
-			return diag.Errorf("error setting VM devices: %s", err)
-		}
+	for key, value := range flattenVMAdditionalProperties(vm.AdditionalProperties) {
+		res[key] = value
 	}
 
-	if resp.Status != nil {
-		if err := d.Set("status", flattenVMStatus(*resp.Status)); err != nil {
-			return diag.Errorf("error setting VM status: %s", err)
-		}
+	if vm.Devices != nil {
+		res["device"] = flattenVMDevices(vm.Devices)
 	}
 
-	d.SetId(strconv.Itoa(int(resp.Id)))
+	if vm.Status != nil {
+		res["status"] = flattenVMStatus(*vm.Status)
+	}
 
-	return diags
+	return res
 }
 
 func flattenVMDevices(d []api.VMDevice) []interface{} {
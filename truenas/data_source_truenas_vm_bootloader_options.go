@@ -0,0 +1,45 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"time"
+)
+
+func dataSourceTrueNASVMBootloaderOptions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get bootloaders supported by VM `bootloader` attribute",
+		ReadContext: dataSourceTrueNASVMBootloaderOptionsRead,
+		Schema: map[string]*schema.Schema{
+			"options": &schema.Schema{
+				Description: "Map of bootloader value to its display name, eg. `UEFI_CSM` => `Legacy BIOS`",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASVMBootloaderOptionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var options map[string]string
+
+	if err := apiGet(ctx, m, "/vm/bootloader_options", nil, &options); err != nil {
+		return diag.Errorf("error getting bootloader options: %s", err)
+	}
+
+	if err := d.Set("options", options); err != nil {
+		return diag.Errorf("error setting bootloader options: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
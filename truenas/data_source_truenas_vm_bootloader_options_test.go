@@ -0,0 +1,23 @@
+package truenas
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceTruenasVMBootloaderOptions_basic(t *testing.T) {
+	resourceName := "data.truenas_vm_bootloader_options.all"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "truenas_vm_bootloader_options" "all" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "options.UEFI"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,45 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"time"
+)
+
+func dataSourceTrueNASVMCPUModels() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get CPU models supported by VM `cpu_model` attribute (SCALE only)",
+		ReadContext: dataSourceTrueNASVMCPUModelsRead,
+		Schema: map[string]*schema.Schema{
+			"models": &schema.Schema{
+				Description: "Sorted list of supported CPU models",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASVMCPUModelsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var choices map[string]string
+
+	if err := apiGet(ctx, m, "/vm/cpu_model_choices", nil, &choices); err != nil {
+		return diag.Errorf("error getting CPU models: %s", err)
+	}
+
+	if err := d.Set("models", sortedMapKeys(choices)); err != nil {
+		return diag.Errorf("error setting CPU models: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
@@ -0,0 +1,46 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"time"
+)
+
+func dataSourceTrueNASVMMemoryAvailable() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get memory available for VMs",
+		ReadContext: dataSourceTrueNASVMMemoryAvailableRead,
+		Schema: map[string]*schema.Schema{
+			"overcommit": &schema.Schema{
+				Description: "Include memory reserved by stopped VMs (overcommit)",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"available": &schema.Schema{
+				Description: "Memory available for VMs (bytes)",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASVMMemoryAvailableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var available int64
+
+	if err := apiPost(ctx, m, "/vm/get_available_memory", d.Get("overcommit").(bool), &available); err != nil {
+		return diag.Errorf("error getting available VM memory: %s", err)
+	}
+
+	d.Set("available", available)
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
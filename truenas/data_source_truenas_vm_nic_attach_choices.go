@@ -0,0 +1,45 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"time"
+)
+
+func dataSourceTrueNASVMNICAttachChoices() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get host interfaces and bridges VM `NIC` devices can attach to",
+		ReadContext: dataSourceTrueNASVMNICAttachChoicesRead,
+		Schema: map[string]*schema.Schema{
+			"choices": &schema.Schema{
+				Description: "Sorted list of interface names, use as `nic_attach` attribute of `NIC` VM device",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASVMNICAttachChoicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var choices map[string]string
+
+	if err := apiGet(ctx, m, "/vm/device/nic_attach_choices", nil, &choices); err != nil {
+		return diag.Errorf("error getting NIC attach choices: %s", err)
+	}
+
+	if err := d.Set("choices", sortedMapKeys(choices)); err != nil {
+		return diag.Errorf("error setting NIC attach choices: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
@@ -0,0 +1,23 @@
+package truenas
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceTruenasVMNICAttachChoices_basic(t *testing.T) {
+	resourceName := "data.truenas_vm_nic_attach_choices.all"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "truenas_vm_nic_attach_choices" "all" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "choices.0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,199 @@
+package truenas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"sort"
+	"strconv"
+	"time"
+)
+
+func dataSourceTrueNASVMPCIDevices() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get PCI devices available for VM passthrough",
+		ReadContext: dataSourceTrueNASVMPCIDevicesRead,
+		Schema: map[string]*schema.Schema{
+			"available_only": &schema.Schema{
+				Description: "Only return devices that can be attached to a VM",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"devices": &schema.Schema{
+				Description: "List of PCI devices",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Description: "PCI device ID, use as `pptdev` attribute of `PCI` VM device",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": &schema.Schema{
+							Description: "Device description",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"controller_type": &schema.Schema{
+							Description: "Controller type, eg. `VGA compatible controller`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"vendor": &schema.Schema{
+							Description: "Device vendor",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"product": &schema.Schema{
+							Description: "Device product",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"available": &schema.Schema{
+							Description: "`true` if device can be attached to a VM",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"critical": &schema.Schema{
+							Description: "`true` if device is critical to the host and should not be isolated",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"iommu_group": &schema.Schema{
+							Description: "IOMMU group number",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"drivers": &schema.Schema{
+							Description: "Host drivers bound to the device",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type vmPCIDevice struct {
+	Capability struct {
+		Product *string `json:"product"`
+		Vendor  *string `json:"vendor"`
+	} `json:"capability"`
+	ControllerType *string  `json:"controller_type"`
+	Description    string   `json:"description"`
+	Available      bool     `json:"available"`
+	Critical       bool     `json:"critical"`
+	Drivers        []string `json:"drivers"`
+	IommuGroup     *struct {
+		Number int `json:"number"`
+	} `json:"iommu_group"`
+}
+
+func dataSourceTrueNASVMPCIDevicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	version, err := getSystemVersion(ctx, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// bhyve on CORE only reports device names
+	path := "/vm/device/pptdev_choices"
+
+	if version.Scale {
+		path = "/vm/device/passthrough_device_choices"
+	}
+
+	var choices map[string]json.RawMessage
+
+	if err := apiGet(ctx, m, path, nil, &choices); err != nil {
+		return diag.Errorf("error getting PCI devices: %s", err)
+	}
+
+	devices, err := flattenVMPCIDevices(choices, d.Get("available_only").(bool))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("devices", devices); err != nil {
+		return diag.Errorf("error setting PCI devices: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// flattenVMPCIDevices returns devices sorted by ID, choices are device names on CORE and device details on SCALE
+func flattenVMPCIDevices(choices map[string]json.RawMessage, availableOnly bool) ([]interface{}, error) {
+	ids := make([]string, 0, len(choices))
+
+	for id := range choices {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	devices := make([]interface{}, 0, len(ids))
+
+	for _, id := range ids {
+		device := vmPCIDevice{}
+
+		var name string
+
+		if err := json.Unmarshal(choices[id], &name); err == nil {
+			device.Description = name
+			device.Available = true
+		} else if err := json.Unmarshal(choices[id], &device); err != nil {
+			return nil, fmt.Errorf("error decoding PCI device %s: %s", id, err)
+		}
+
+		if availableOnly && !device.Available {
+			continue
+		}
+
+		devices = append(devices, flattenVMPCIDevice(id, device))
+	}
+
+	return devices, nil
+}
+
+func flattenVMPCIDevice(id string, device vmPCIDevice) map[string]interface{} {
+	res := map[string]interface{}{
+		"id":          id,
+		"description": device.Description,
+		"available":   device.Available,
+		"critical":    device.Critical,
+		"drivers":     flattenStringList(device.Drivers),
+	}
+
+	if device.ControllerType != nil {
+		res["controller_type"] = *device.ControllerType
+	}
+
+	if device.Capability.Vendor != nil {
+		res["vendor"] = *device.Capability.Vendor
+	}
+
+	if device.Capability.Product != nil {
+		res["product"] = *device.Capability.Product
+	}
+
+	if device.IommuGroup != nil {
+		res["iommu_group"] = device.IommuGroup.Number
+	}
+
+	return res
+}
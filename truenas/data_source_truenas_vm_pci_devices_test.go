@@ -0,0 +1,93 @@
+package truenas
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFlattenVMPCIDevicesCore(t *testing.T) {
+	var choices map[string]json.RawMessage
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"pci0:3:0:0": "pci0:3:0:0", "pci0:1:0:0": "pci0:1:0:0"}`), &choices))
+
+	devices, err := flattenVMPCIDevices(choices, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"id":          "pci0:1:0:0",
+			"description": "pci0:1:0:0",
+			"available":   true,
+			"critical":    false,
+			"drivers":     []interface{}{},
+		},
+		map[string]interface{}{
+			"id":          "pci0:3:0:0",
+			"description": "pci0:3:0:0",
+			"available":   true,
+			"critical":    false,
+			"drivers":     []interface{}{},
+		},
+	}, devices)
+}
+
+func TestFlattenVMPCIDevicesScale(t *testing.T) {
+	var choices map[string]json.RawMessage
+
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"pci_0000_01_00_0": {
+			"capability": {"class": "0x030000", "domain": "0", "bus": "1", "slot": "0", "function": "0", "product": "GP107GL [Quadro P1000]", "vendor": "NVIDIA Corporation"},
+			"controller_type": "VGA compatible controller",
+			"iommu_group": {"number": 14, "addresses": []},
+			"available": true,
+			"drivers": ["vfio-pci"],
+			"error": null,
+			"device_path": "/sys/bus/pci/devices/0000:01:00.0",
+			"reset_mechanism_defined": true,
+			"description": "NVIDIA Corporation GP107GL [Quadro P1000]",
+			"critical": false
+		},
+		"pci_0000_00_1f_2": {
+			"capability": {"product": null, "vendor": null},
+			"controller_type": null,
+			"iommu_group": null,
+			"available": false,
+			"drivers": ["ahci"],
+			"description": "SATA controller",
+			"critical": true
+		}
+	}`), &choices))
+
+	devices, err := flattenVMPCIDevices(choices, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"id":          "pci_0000_00_1f_2",
+			"description": "SATA controller",
+			"available":   false,
+			"critical":    true,
+			"drivers":     []interface{}{"ahci"},
+		},
+		map[string]interface{}{
+			"id":              "pci_0000_01_00_0",
+			"description":     "NVIDIA Corporation GP107GL [Quadro P1000]",
+			"controller_type": "VGA compatible controller",
+			"vendor":          "NVIDIA Corporation",
+			"product":         "GP107GL [Quadro P1000]",
+			"available":       true,
+			"critical":        false,
+			"iommu_group":     14,
+			"drivers":         []interface{}{"vfio-pci"},
+		},
+	}, devices)
+
+	devices, err = flattenVMPCIDevices(choices, true)
+	assert.NoError(t, err)
+	assert.Len(t, devices, 1)
+	assert.Equal(t, "pci_0000_01_00_0", devices[0].(map[string]interface{})["id"])
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"pci_0000_02_00_0": 1}`), &choices))
+
+	_, err = flattenVMPCIDevices(choices, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package truenas
+
+import (
+	"context"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func dataSourceTrueNASVMs() *schema.Resource {
+	vm := vmDataSourceSchema()
+
+	vm["vm_id"] = &schema.Schema{
+		Description: "VM ID",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Get list of VMs, optionally filtered",
+		ReadContext: dataSourceTrueNASVMsRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": &schema.Schema{
+				Description:  "Only return VMs with name matching this regular expression",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"state": &schema.Schema{
+				Description:  "Only return VMs in this state: `RUNNING`, `STOPPED` or `SUSPENDED`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"RUNNING", "STOPPED", "SUSPENDED"}, true),
+			},
+			"autostart": &schema.Schema{
+				Description: "Only return VMs with matching autostart setting",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"ids": &schema.Schema{
+				Description: "IDs of matching VMs",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vms": &schema.Schema{
+				Description: "Matching VMs, same attributes as `truenas_vm` data source",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: vm,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTrueNASVMsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*providerMeta)
+
+	resp, _, err := c.VmApi.ListVMS(ctx).Execute()
+
+	if err != nil {
+		var body []byte
+		if apiErr, ok := err.(*api.GenericOpenAPIError); ok {
+			body = apiErr.Body()
+		}
+		return diag.Errorf("error getting VMs: %s\n%s", err, body)
+	}
+
+	var nameRegex *regexp.Regexp
+
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	state := d.Get("state").(string)
+	autostart, filterAutostart := d.GetOkExists("autostart")
+
+	ids := make([]interface{}, 0, len(resp))
+	vms := make([]interface{}, 0, len(resp))
+
+	for _, vm := range resp {
+		if nameRegex != nil && !nameRegex.MatchString(vm.Name) {
+			continue
+		}
+
+		if state != "" && (vm.Status == nil || vm.Status.State == nil || !strings.EqualFold(*vm.Status.State, state)) {
+			continue
+		}
+
+		if filterAutostart && (vm.Autostart == nil || *vm.Autostart != autostart.(bool)) {
+			continue
+		}
+
+		ids = append(ids, strconv.Itoa(int(vm.Id)))
+		vms = append(vms, flattenVM(vm))
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.Errorf("error setting VM ids: %s", err)
+	}
+
+	if err := d.Set("vms", vms); err != nil {
+		return diag.Errorf("error setting VMs: %s", err)
+	}
+
+	// always run
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
@@ -0,0 +1,48 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"strings"
+	"testing"
+)
+
+func TestAccDataSourceTruenasVMs_basic(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	// VM name must be alphanumeric
+	name := fmt.Sprintf("%s%s", strings.Replace(testResourcePrefix, "-", "", -1), suffix)
+	resourceName := "data.truenas_vms.vms"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourceTruenasVMsConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "vms.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "vms.0.name", name),
+					resource.TestCheckResourceAttr(resourceName, "vms.0.description", "Test VM"),
+					resource.TestCheckResourceAttr(resourceName, "vms.0.autostart", "false"),
+					resource.TestCheckResourceAttrPair(resourceName, "ids.0", "truenas_vm.vm", "vm_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceTruenasVMsConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "truenas_vm" "vm" {
+		  name = "%s"
+		  description = "Test VM"
+		  autostart = false
+		}
+
+		data "truenas_vms" "vms" {
+			name_regex = "^${truenas_vm.vm.name}$"
+			autostart = false
+		}
+	`, name)
+}
@@ -109,7 +109,7 @@ func dataSourceTrueNASZVOL() *schema.Resource {
 func dataSourceTrueNASZVOLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id := d.Get("zvol_id").(string)
 
 	resp, _, err := c.DatasetApi.GetDataset(ctx, id).Execute()
@@ -1,5 +1,14 @@
 package truenas
 
+import (
+	"crypto/rand"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"math/big"
+	"sort"
+	"sync"
+)
+
 func flattenInt64List(list []int64) []interface{} {
 	result := make([]interface{}, 0, len(list))
 	for _, num := range list {
@@ -60,3 +69,229 @@ func convertStringMap(v map[string]interface{}) map[string]string {
 	}
 	return m
 }
+
+// getNullableStringPtr returns nil for empty string, used to reset nullable API attributes
+func getNullableStringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return getStringPtr(s)
+}
+
+func sortedMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// mutexKV serializes read-modify-write API calls on the same remote object, eg. group membership
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+func (m *mutexKV) Lock(key string) {
+	m.get(key).Lock()
+}
+
+func (m *mutexKV) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex, ok := m.store[key]
+
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+
+	return mutex
+}
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{
+		store: make(map[string]*sync.Mutex),
+	}
+}
+
+const passwordCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#%*+-=?@^_"
+
+// generatePassword returns cryptographically random password
+func generatePassword(length int) (string, error) {
+	result := make([]byte, length)
+	max := big.NewInt(int64(len(passwordCharset)))
+
+	for i := range result {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		result[i] = passwordCharset[n.Int64()]
+	}
+
+	return string(result), nil
+}
+
+// isConfigured returns true if top level attribute is set in configuration, including false and zero values
+func isConfigured(d *schema.ResourceData, key string) bool {
+	config := d.GetRawConfig()
+
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+
+	return !config.GetAttr(key).IsNull()
+}
+
+// expandConfigAttributes returns singleton config payload keyed by attribute name (same as API name),
+// with configured attributes on creation and changed attributes on update
+func expandConfigAttributes(d *schema.ResourceData, attrs []string) map[string]interface{} {
+	input := map[string]interface{}{}
+
+	for _, attr := range attrs {
+		if d.IsNewResource() && !isConfigured(d, attr) || !d.IsNewResource() && !d.HasChange(attr) {
+			continue
+		}
+
+		switch v := d.Get(attr).(type) {
+		case *schema.Set:
+			input[attr] = expandStrings(v.List())
+		case []interface{}:
+			input[attr] = expandStrings(v)
+		default:
+			input[attr] = v
+		}
+	}
+
+	return input
+}
+
+// flattenConfigAttributes sets attributes from singleton config response, null values are set to zero value
+func flattenConfigAttributes(d *schema.ResourceData, config map[string]interface{}, attrs []string) error {
+	for _, attr := range attrs {
+		value, ok := config[attr]
+
+		if !ok {
+			continue
+		}
+
+		// JSON numbers
+		if f, ok := value.(float64); ok {
+			value = int(f)
+		}
+
+		if err := d.Set(attr, value); err != nil {
+			return fmt.Errorf("error setting %s: %s", attr, err)
+		}
+	}
+
+	return nil
+}
+
+// computedSchema returns copy of resource schema with all attributes computed, for data sources of singleton configs
+func computedSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	res := make(map[string]*schema.Schema, len(s))
+
+	for key, v := range s {
+		c := &schema.Schema{
+			Type:        v.Type,
+			Description: v.Description,
+			Computed:    true,
+			Sensitive:   v.Sensitive,
+			Set:         v.Set,
+		}
+
+		switch elem := v.Elem.(type) {
+		case *schema.Resource:
+			c.Elem = &schema.Resource{
+				Schema: computedSchema(elem.Schema),
+			}
+		case *schema.Schema:
+			c.Elem = &schema.Schema{
+				Type: elem.Type,
+			}
+		}
+
+		res[key] = c
+	}
+
+	return res
+}
+
+// expandTypedAttributes returns type and attributes of the configured block, blocks maps block names to API types,
+// empty optional attributes are omitted as they are nullable or have API defaults
+func expandTypedAttributes(d *schema.ResourceData, blocks map[string]string) (string, map[string]interface{}) {
+	attributes := map[string]interface{}{}
+
+	for block, t := range blocks {
+		l, ok := d.Get(block).([]interface{})
+
+		if !ok || len(l) == 0 || l[0] == nil {
+			continue
+		}
+
+		for k, v := range l[0].(map[string]interface{}) {
+			if v == "" || v == 0 {
+				continue
+			}
+			attributes[k] = v
+		}
+
+		return t, attributes
+	}
+
+	return "", attributes
+}
+
+// flattenTypedAttributes returns block of attributes in blockSchema, sensitive attributes not returned by API are kept from state
+func flattenTypedAttributes(d *schema.ResourceData, block string, blockSchema map[string]*schema.Schema, attributes map[string]interface{}) []interface{} {
+	current := map[string]interface{}{}
+
+	if l, ok := d.Get(block).([]interface{}); ok && len(l) > 0 && l[0] != nil {
+		current = l[0].(map[string]interface{})
+	}
+
+	res := map[string]interface{}{}
+
+	for k, s := range blockSchema {
+		v, ok := attributes[k]
+
+		if !ok || v == nil {
+			if s.Sensitive {
+				res[k] = current[k]
+			}
+			continue
+		}
+
+		// JSON numbers
+		switch value := v.(type) {
+		case float64:
+			v = int(value)
+		case []interface{}:
+			items := make([]interface{}, len(value))
+
+			for i, item := range value {
+				if f, ok := item.(float64); ok {
+					item = int(f)
+				}
+				items[i] = item
+			}
+
+			v = items
+		}
+
+		res[k] = v
+	}
+
+	return []interface{}{res}
+}
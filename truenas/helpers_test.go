@@ -0,0 +1,37 @@
+package truenas
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestComputedSchema(t *testing.T) {
+	s := computedSchema(map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"entry": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"enabled": &schema.Schema{
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+				},
+			},
+		},
+	})
+
+	assert.NoError(t, schema.InternalMap(s).InternalValidate(nil))
+	assert.True(t, s["name"].Computed)
+	assert.False(t, s["name"].Required)
+	assert.False(t, s["name"].ForceNew)
+	assert.Nil(t, s["entry"].Elem.(*schema.Resource).Schema["enabled"].Default)
+}
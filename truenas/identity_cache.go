@@ -0,0 +1,233 @@
+package truenas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"sync"
+)
+
+// identityCache keeps users and groups listed once per provider run, so resources resolving
+// user and group IDs do not list or fetch them one by one. Any user or group write must invalidate it.
+type identityCache struct {
+	lock sync.Mutex
+
+	users       []api.User
+	usersByID   map[int]*api.User
+	usersByUID  map[int]*api.User
+	usersByName map[string]*api.User
+
+	groups       []APIGroup
+	groupsByID   map[int]*APIGroup
+	groupsByGID  map[int]*APIGroup
+	groupsByName map[string]*APIGroup
+}
+
+func getIdentityCache(m interface{}) *identityCache {
+	return m.(*providerMeta).identities
+}
+
+// Users returns all users, listing them on first use
+func (c *identityCache) Users(ctx context.Context, m interface{}) ([]api.User, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.loadUsers(ctx, m); err != nil {
+		return nil, err
+	}
+
+	return c.users, nil
+}
+
+// Groups returns all groups, listing them on first use
+func (c *identityCache) Groups(ctx context.Context, m interface{}) ([]APIGroup, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.loadGroups(ctx, m); err != nil {
+		return nil, err
+	}
+
+	return c.groups, nil
+}
+
+// UserByID returns user by TrueNAS user ID, nil if not found
+func (c *identityCache) UserByID(ctx context.Context, m interface{}, id int) (*api.User, error) {
+	return c.lookupUser(ctx, m, func() *api.User { return c.usersByID[id] })
+}
+
+// UserByUID returns user by UID, nil if not found
+func (c *identityCache) UserByUID(ctx context.Context, m interface{}, uid int) (*api.User, error) {
+	return c.lookupUser(ctx, m, func() *api.User { return c.usersByUID[uid] })
+}
+
+// UserByName returns user by username, nil if not found
+func (c *identityCache) UserByName(ctx context.Context, m interface{}, name string) (*api.User, error) {
+	return c.lookupUser(ctx, m, func() *api.User { return c.usersByName[name] })
+}
+
+// GroupByID returns group by TrueNAS group ID, nil if not found
+func (c *identityCache) GroupByID(ctx context.Context, m interface{}, id int) (*APIGroup, error) {
+	return c.lookupGroup(ctx, m, func() *APIGroup { return c.groupsByID[id] })
+}
+
+// GroupByGID returns group by GID, nil if not found
+func (c *identityCache) GroupByGID(ctx context.Context, m interface{}, gid int) (*APIGroup, error) {
+	return c.lookupGroup(ctx, m, func() *APIGroup { return c.groupsByGID[gid] })
+}
+
+// GroupByName returns group by name, nil if not found
+func (c *identityCache) GroupByName(ctx context.Context, m interface{}, name string) (*APIGroup, error) {
+	return c.lookupGroup(ctx, m, func() *APIGroup { return c.groupsByName[name] })
+}
+
+// InvalidateUsers drops cached users, next lookup lists them again
+func (c *identityCache) InvalidateUsers() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.users = nil
+}
+
+// InvalidateGroups drops cached groups, next lookup lists them again
+func (c *identityCache) InvalidateGroups() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.groups = nil
+}
+
+// Invalidate drops both users and groups, user writes can change groups (primary group creation, membership) and vice versa
+func (c *identityCache) Invalidate() {
+	c.InvalidateUsers()
+	c.InvalidateGroups()
+}
+
+// lookupUser lists users again on cache miss, in case user was created outside of this provider run
+func (c *identityCache) lookupUser(ctx context.Context, m interface{}, find func() *api.User) (*api.User, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.loadUsers(ctx, m); err != nil {
+		return nil, err
+	}
+
+	if user := find(); user != nil {
+		return user, nil
+	}
+
+	c.users = nil
+
+	if err := c.loadUsers(ctx, m); err != nil {
+		return nil, err
+	}
+
+	return find(), nil
+}
+
+// lookupGroup lists groups again on cache miss, in case group was created outside of this provider run
+func (c *identityCache) lookupGroup(ctx context.Context, m interface{}, find func() *APIGroup) (*APIGroup, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.loadGroups(ctx, m); err != nil {
+		return nil, err
+	}
+
+	if group := find(); group != nil {
+		return group, nil
+	}
+
+	c.groups = nil
+
+	if err := c.loadGroups(ctx, m); err != nil {
+		return nil, err
+	}
+
+	return find(), nil
+}
+
+// loadUsers must be called with lock held
+func (c *identityCache) loadUsers(ctx context.Context, m interface{}) error {
+	if c.users != nil {
+		return nil
+	}
+
+	client := m.(*providerMeta)
+
+	users, _, err := client.UserApi.ListUsers(ctx).Execute()
+
+	if err != nil {
+		var body []byte
+		if apiErr, ok := err.(*api.GenericOpenAPIError); ok {
+			body = apiErr.Body()
+		}
+		return fmt.Errorf("error getting users: %s\n%s", err, body)
+	}
+
+	c.usersByID = make(map[int]*api.User, len(users))
+	c.usersByUID = make(map[int]*api.User, len(users))
+	c.usersByName = make(map[string]*api.User, len(users))
+
+	for i := range users {
+		user := &users[i]
+		c.usersByID[int(user.Id)] = user
+		c.usersByName[user.Username] = user
+
+		if user.Uid != nil {
+			c.usersByUID[int(*user.Uid)] = user
+		}
+	}
+
+	if users == nil {
+		users = []api.User{}
+	}
+
+	c.users = users
+
+	return nil
+}
+
+// loadGroups must be called with lock held
+func (c *identityCache) loadGroups(ctx context.Context, m interface{}) error {
+	if c.groups != nil {
+		return nil
+	}
+
+	client := m.(*providerMeta)
+
+	// It seems the only way to map gid to group.id is to enumerate the whole list :(
+	groupsResponse, err := client.GroupApi.ListGroups(ctx).Execute()
+	if err != nil {
+		return fmt.Errorf("error getting groups: %s", err)
+	}
+
+	defer groupsResponse.Body.Close()
+
+	groups := []APIGroup{}
+	decoder := json.NewDecoder(groupsResponse.Body)
+	err = decoder.Decode(&groups)
+	if err != nil {
+		return fmt.Errorf("error decoding groups response: %s", err)
+	}
+
+	if groups == nil {
+		groups = []APIGroup{}
+	}
+
+	c.groupsByID = make(map[int]*APIGroup, len(groups))
+	c.groupsByGID = make(map[int]*APIGroup, len(groups))
+	c.groupsByName = make(map[string]*APIGroup, len(groups))
+
+	for i := range groups {
+		group := &groups[i]
+		c.groupsByID[group.Id] = group
+		c.groupsByGID[group.Gid] = group
+		c.groupsByName[group.Group] = group
+	}
+
+	c.groups = groups
+
+	return nil
+}
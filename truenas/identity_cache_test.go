@@ -0,0 +1,58 @@
+package truenas
+
+import (
+	"context"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIdentityCache_GroupLookups(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"id": 1, "gid": 0, "group": "wheel", "builtin": true, "sudo_nopasswd": true, "users": [1]},
+			{"id": 42, "gid": 1000, "group": "media", "builtin": false, "users": [30, 31]}
+		]`))
+	}))
+	defer server.Close()
+
+	config := api.NewConfiguration()
+	config.Servers = api.ServerConfigurations{{URL: server.URL}}
+	client := newProviderMeta(api.NewAPIClient(config))
+
+	ctx := context.Background()
+	cache := getIdentityCache(client)
+
+	group, err := cache.GroupByGID(ctx, client, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, "media", group.Group)
+	assert.Equal(t, []int{30, 31}, group.Users)
+
+	group, err = cache.GroupByName(ctx, client, "wheel")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, group.Id)
+	assert.True(t, group.SudoNopasswd)
+
+	group, err = cache.GroupByID(ctx, client, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, group.Gid)
+	assert.Equal(t, 1, requests)
+
+	// miss lists groups again once
+	group, err = cache.GroupByGID(ctx, client, 2000)
+	assert.NoError(t, err)
+	assert.Nil(t, group)
+	assert.Equal(t, 2, requests)
+
+	cache.Invalidate()
+
+	_, err = cache.Groups(ctx, client)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, requests)
+}
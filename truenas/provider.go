@@ -33,24 +33,66 @@ func Provider() *schema.Provider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"truenas_cronjob":   resourceTrueNASCronjob(),
-			"truenas_dataset":   resourceTrueNASDataset(),
-			"truenas_group":     resourceTrueNASGroup(),
-			"truenas_share_nfs": resourceTrueNASShareNFS(),
-			"truenas_share_smb": resourceTrueNASShareSMB(),
-			"truenas_user":      resourceTrueNASUser(),
-			"truenas_zvol":      resourceTrueNASZVOL(),
-			"truenas_vm":        resourceTrueNASVM(),
+			"truenas_acme_dns_authenticator": resourceTrueNASACMEDNSAuthenticator(),
+			"truenas_alert_classes":          resourceTrueNASAlertClasses(),
+			"truenas_alert_service":          resourceTrueNASAlertService(),
+			"truenas_api_key":                resourceTrueNASAPIKey(),
+			"truenas_certificate":            resourceTrueNASCertificate(),
+			"truenas_certificate_authority":  resourceTrueNASCertificateAuthority(),
+			"truenas_cloud_credential":       resourceTrueNASCloudCredential(),
+			"truenas_cloud_sync_task":        resourceTrueNASCloudSyncTask(),
+			"truenas_cronjob":                resourceTrueNASCronjob(),
+			"truenas_dataset":                resourceTrueNASDataset(),
+			"truenas_group":                  resourceTrueNASGroup(),
+			"truenas_group_membership":       resourceTrueNASGroupMembership(),
+			"truenas_group_members":          resourceTrueNASGroupMembers(),
+			"truenas_init_script":            resourceTrueNASInitScript(),
+			"truenas_mail_config":            resourceTrueNASMailConfig(),
+			"truenas_network_configuration":  resourceTrueNASNetworkConfiguration(),
+			"truenas_network_interface":      resourceTrueNASNetworkInterface(),
+			"truenas_nfs_config":             resourceTrueNASNFSConfig(),
+			"truenas_ntp_server":             resourceTrueNASNTPServer(),
+			"truenas_rsync_module":           resourceTrueNASRsyncModule(),
+			"truenas_rsync_task":             resourceTrueNASRsyncTask(),
+			"truenas_service":                resourceTrueNASService(),
+			"truenas_share_nfs":              resourceTrueNASShareNFS(),
+			"truenas_share_smb":              resourceTrueNASShareSMB(),
+			"truenas_share_smb_acl":          resourceTrueNASShareSMBACL(),
+			"truenas_smb_config":             resourceTrueNASSMBConfig(),
+			"truenas_static_route":           resourceTrueNASStaticRoute(),
+			"truenas_ssh_config":             resourceTrueNASSSHConfig(),
+			"truenas_system_general":         resourceTrueNASSystemGeneral(),
+			"truenas_tunable":                resourceTrueNASTunable(),
+			"truenas_user":                   resourceTrueNASUser(),
+			"truenas_zvol":                   resourceTrueNASZVOL(),
+			"truenas_vm":                     resourceTrueNASVM(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"truenas_alerts":                dataSourceTrueNASAlerts(),
+			"truenas_certificates":          dataSourceTrueNASCertificates(),
 			"truenas_cronjob":               dataSourceTrueNASCronjob(),
 			"truenas_dataset":               dataSourceTrueNASDataset(),
+			"truenas_group":                 dataSourceTrueNASGroup(),
+			"truenas_groups":                dataSourceTrueNASGroups(),
 			"truenas_network_configuration": dataSourceTrueNASNetworkConfiguration(),
+			"truenas_nfs_config":            dataSourceTrueNASNFSConfig(),
 			"truenas_pool_ids":              dataSourceTrueNASPoolIDs(),
 			"truenas_service":               dataSourceTrueNASService(),
 			"truenas_share_nfs":             dataSourceTrueNASShareNFS(),
 			"truenas_share_smb":             dataSourceTrueNASShareSMB(),
+			"truenas_smb_config":            dataSourceTrueNASSMBConfig(),
+			"truenas_ssh_config":            dataSourceTrueNASSSHConfig(),
+			"truenas_static_routes":         dataSourceTrueNASStaticRoutes(),
+			"truenas_system_routes":         dataSourceTrueNASSystemRoutes(),
+			"truenas_user":                  dataSourceTrueNASUser(),
+			"truenas_users":                 dataSourceTrueNASUsers(),
 			"truenas_vm":                    dataSourceTrueNASVM(),
+			"truenas_vm_bootloader_options": dataSourceTrueNASVMBootloaderOptions(),
+			"truenas_vm_cpu_models":         dataSourceTrueNASVMCPUModels(),
+			"truenas_vm_memory_available":   dataSourceTrueNASVMMemoryAvailable(),
+			"truenas_vm_nic_attach_choices": dataSourceTrueNASVMNICAttachChoices(),
+			"truenas_vm_pci_devices":        dataSourceTrueNASVMPCIDevices(),
+			"truenas_vms":                   dataSourceTrueNASVMs(),
 			"truenas_zvol":                  dataSourceTrueNASZVOL(),
 		},
 		ConfigureContextFunc: providerConfigure,
@@ -79,6 +121,19 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	config.Debug = debug
 	config.HTTPClient = tc
 
-	c := api.NewAPIClient(config)
+	c := newProviderMeta(api.NewAPIClient(config))
 	return c, diags
 }
+
+// providerMeta is configured provider meta, SDK client with caches living as long as the configured provider
+type providerMeta struct {
+	*api.APIClient
+	identities *identityCache
+}
+
+func newProviderMeta(client *api.APIClient) *providerMeta {
+	return &providerMeta{
+		APIClient:  client,
+		identities: &identityCache{},
+	}
+}
@@ -0,0 +1,150 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"strconv"
+)
+
+// ACMEDNSAuthenticator is acme.dns.authenticator middleware object,
+// authenticator type is part of attributes since SCALE 24.10
+type ACMEDNSAuthenticator struct {
+	Id            int                    `json:"id,omitempty"`
+	Name          string                 `json:"name"`
+	Authenticator string                 `json:"authenticator,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes"`
+}
+
+func resourceTrueNASACMEDNSAuthenticator() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates an ACME DNS authenticator, used to complete DNS-01 challenges for ACME certificates. Attributes are not read back from TrueNAS.",
+		CreateContext: resourceTrueNASACMEDNSAuthenticatorCreate,
+		ReadContext:   resourceTrueNASACMEDNSAuthenticatorRead,
+		UpdateContext: resourceTrueNASACMEDNSAuthenticatorUpdate,
+		DeleteContext: resourceTrueNASACMEDNSAuthenticatorDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Description: "Authenticator name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"authenticator": &schema.Schema{
+				Description: "DNS provider, eg. `cloudflare`, `route53`, `ovh`, `digitalocean`, `shell`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"attributes": &schema.Schema{
+				Description: "Provider specific attributes, eg. `api_token` for `cloudflare`, `access_key_id` and `secret_access_key` for `route53`",
+				Type:        schema.TypeMap,
+				Required:    true,
+				Sensitive:   true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceTrueNASACMEDNSAuthenticatorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var authenticator ACMEDNSAuthenticator
+
+	if err := apiGet(ctx, m, "/acme/dns/authenticator/id/"+d.Id(), nil, &authenticator); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS ACME DNS authenticator (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting ACME DNS authenticator: %s", err)
+	}
+
+	d.Set("name", authenticator.Name)
+
+	if authenticator.Authenticator != "" {
+		d.Set("authenticator", authenticator.Authenticator)
+	} else if name, ok := authenticator.Attributes["authenticator"].(string); ok {
+		d.Set("authenticator", name)
+	}
+
+	return diags
+}
+
+func resourceTrueNASACMEDNSAuthenticatorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input, err := expandACMEDNSAuthenticator(ctx, m, d)
+
+	if err != nil {
+		return diag.Errorf("error creating ACME DNS authenticator: %s", err)
+	}
+
+	var authenticator ACMEDNSAuthenticator
+
+	if err := apiPost(ctx, m, "/acme/dns/authenticator", input, &authenticator); err != nil {
+		return diag.Errorf("error creating ACME DNS authenticator: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(authenticator.Id))
+
+	return resourceTrueNASACMEDNSAuthenticatorRead(ctx, d, m)
+}
+
+func resourceTrueNASACMEDNSAuthenticatorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input, err := expandACMEDNSAuthenticator(ctx, m, d)
+
+	if err != nil {
+		return diag.Errorf("error updating ACME DNS authenticator: %s", err)
+	}
+
+	// authenticator type cannot be changed
+	input.Authenticator = ""
+
+	if err := apiPut(ctx, m, "/acme/dns/authenticator/id/"+d.Id(), input, nil); err != nil {
+		return diag.Errorf("error updating ACME DNS authenticator: %s", err)
+	}
+
+	return resourceTrueNASACMEDNSAuthenticatorRead(ctx, d, m)
+}
+
+func resourceTrueNASACMEDNSAuthenticatorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS ACME DNS authenticator: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/acme/dns/authenticator/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting ACME DNS authenticator: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS ACME DNS authenticator (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func expandACMEDNSAuthenticator(ctx context.Context, m interface{}, d *schema.ResourceData) (*ACMEDNSAuthenticator, error) {
+	authenticator := &ACMEDNSAuthenticator{
+		Name:       d.Get("name").(string),
+		Attributes: d.Get("attributes").(map[string]interface{}),
+	}
+
+	version, err := getSystemVersion(ctx, m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if version.ScaleAtLeast(24, 10) {
+		authenticator.Attributes["authenticator"] = d.Get("authenticator").(string)
+	} else {
+		authenticator.Authenticator = d.Get("authenticator").(string)
+	}
+
+	return authenticator, nil
+}
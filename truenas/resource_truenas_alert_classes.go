@@ -0,0 +1,208 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"sort"
+)
+
+const alertClassesID = "alert-classes"
+
+func resourceTrueNASAlertClasses() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages per alert class level and policy overrides. There is only one alert classes configuration per system, classes not listed use TrueNAS defaults. Deleting the resource restores defaults for all classes.",
+		CreateContext: resourceTrueNASAlertClassesCreate,
+		ReadContext:   resourceTrueNASAlertClassesRead,
+		UpdateContext: resourceTrueNASAlertClassesUpdate,
+		DeleteContext: resourceTrueNASAlertClassesDelete,
+		CustomizeDiff: resourceTrueNASAlertClassesCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"class": &schema.Schema{
+				Description: "Alert class override, at least one of `level` or `policy` must be set",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Description: "Alert class name, eg. `ScrubPaused`, `SMART`, `VolumeStatus`",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"level": &schema.Schema{
+							Description:  "Alert level: `INFO`, `NOTICE`, `WARNING`, `ERROR`, `CRITICAL`, `ALERT` or `EMERGENCY`, class default if empty",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(alertLevels, false),
+						},
+						"policy": &schema.Schema{
+							Description:  "How often alert services are notified: `IMMEDIATELY`, `HOURLY`, `DAILY` or `NEVER`, `IMMEDIATELY` if empty",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"IMMEDIATELY", "HOURLY", "DAILY", "NEVER"}, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceTrueNASAlertClassesCustomizeDiff rejects classes without overrides, they are not returned by API
+// and would always show a diff
+func resourceTrueNASAlertClassesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	classes := config.GetAttr("class")
+
+	if classes.IsNull() || !classes.IsKnown() {
+		return nil
+	}
+
+	for _, class := range classes.AsValueSlice() {
+		if !class.IsKnown() || class.IsNull() {
+			continue
+		}
+
+		name := class.GetAttr("name")
+		level := class.GetAttr("level")
+		policy := class.GetAttr("policy")
+
+		// checked again once values are known
+		if !name.IsKnown() || !level.IsKnown() || !policy.IsKnown() {
+			continue
+		}
+
+		if (level.IsNull() || level.AsString() == "") && (policy.IsNull() || policy.AsString() == "") {
+			return fmt.Errorf("alert class %s: at least one of level or policy must be set", name.AsString())
+		}
+	}
+
+	return nil
+}
+
+func resourceTrueNASAlertClassesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var config struct {
+		Classes map[string]map[string]interface{} `json:"classes"`
+	}
+
+	if err := apiGet(ctx, m, "/alertclasses", nil, &config); err != nil {
+		return diag.Errorf("error getting alert classes: %s", err)
+	}
+
+	if err := d.Set("class", flattenAlertClasses(config.Classes)); err != nil {
+		return diag.Errorf("error setting class: %s", err)
+	}
+
+	return diags
+}
+
+func resourceTrueNASAlertClassesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateAlertClasses(ctx, m, d.Get("class").(*schema.Set).List()); err != nil {
+		return diag.Errorf("error creating alert classes: %s", err)
+	}
+
+	d.SetId(alertClassesID)
+
+	return resourceTrueNASAlertClassesRead(ctx, d, m)
+}
+
+func resourceTrueNASAlertClassesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateAlertClasses(ctx, m, d.Get("class").(*schema.Set).List()); err != nil {
+		return diag.Errorf("error updating alert classes: %s", err)
+	}
+
+	return resourceTrueNASAlertClassesRead(ctx, d, m)
+}
+
+func resourceTrueNASAlertClassesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Restoring default TrueNAS alert classes")
+
+	if err := updateAlertClasses(ctx, m, nil); err != nil {
+		return diag.Errorf("error deleting alert classes: %s", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// updateAlertClasses replaces all overrides, classes not listed use defaults
+func updateAlertClasses(ctx context.Context, m interface{}, classes []interface{}) error {
+	input, err := expandAlertClasses(classes)
+
+	if err != nil {
+		return err
+	}
+
+	return apiPut(ctx, m, "/alertclasses", map[string]interface{}{"classes": input}, nil)
+}
+
+func expandAlertClasses(classes []interface{}) (map[string]interface{}, error) {
+	res := map[string]interface{}{}
+
+	for _, item := range classes {
+		class := item.(map[string]interface{})
+		name := class["name"].(string)
+
+		if _, ok := res[name]; ok {
+			return nil, fmt.Errorf("duplicate alert class: %s", name)
+		}
+
+		override := map[string]interface{}{}
+
+		for _, attr := range []string{"level", "policy"} {
+			if v := class[attr].(string); v != "" {
+				override[attr] = v
+			}
+		}
+
+		res[name] = override
+	}
+
+	return res, nil
+}
+
+func flattenAlertClasses(classes map[string]map[string]interface{}) []interface{} {
+	names := make([]string, 0, len(classes))
+
+	for name := range classes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	res := make([]interface{}, 0, len(classes))
+
+	for _, name := range names {
+		level, _ := classes[name]["level"].(string)
+		policy, _ := classes[name]["policy"].(string)
+
+		// classes without level or policy override use defaults
+		if level == "" && policy == "" {
+			continue
+		}
+
+		res = append(res, map[string]interface{}{
+			"name":   name,
+			"level":  level,
+			"policy": policy,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,34 @@
+package truenas
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAlertClasses(t *testing.T) {
+	classes := []interface{}{
+		map[string]interface{}{"name": "ScrubPaused", "level": "INFO", "policy": ""},
+		map[string]interface{}{"name": "SMART", "level": "", "policy": "DAILY"},
+	}
+
+	expanded, err := expandAlertClasses(classes)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"ScrubPaused": map[string]interface{}{"level": "INFO"},
+		"SMART":       map[string]interface{}{"policy": "DAILY"},
+	}, expanded)
+
+	_, err = expandAlertClasses(append(classes, classes[0]))
+	assert.Error(t, err)
+
+	flattened := flattenAlertClasses(map[string]map[string]interface{}{
+		"SMART":        {"policy": "DAILY"},
+		"ScrubPaused":  {"level": "INFO"},
+		"VolumeStatus": {},
+	})
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "SMART", "level": "", "policy": "DAILY"},
+		map[string]interface{}{"name": "ScrubPaused", "level": "INFO", "policy": ""},
+	}, flattened)
+}
@@ -0,0 +1,426 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strconv"
+)
+
+// AlertService is alertservice middleware object, type is part of attributes since SCALE 25.04
+type AlertService struct {
+	Id         int                    `json:"id,omitempty"`
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type,omitempty"`
+	Attributes map[string]interface{} `json:"attributes"`
+	Level      string                 `json:"level"`
+	Enabled    bool                   `json:"enabled"`
+}
+
+// alertLevels are ordered by severity
+var alertLevels = []string{"INFO", "NOTICE", "WARNING", "ERROR", "CRITICAL", "ALERT", "EMERGENCY"}
+
+// alertServiceTypes maps attribute blocks to TrueNAS alert service types
+var alertServiceTypes = map[string]string{
+	"mail":       "Mail",
+	"slack":      "Slack",
+	"pagerduty":  "PagerDuty",
+	"opsgenie":   "OpsGenie",
+	"mattermost": "Mattermost",
+	"telegram":   "Telegram",
+	"snmp_trap":  "SNMPTrap",
+}
+
+func resourceTrueNASAlertService() *schema.Resource {
+	blocks := sortedMapKeys(alertServiceTypes)
+
+	return &schema.Resource{
+		Description:   "Creates an alert service, which sends alerts of the given level or higher. Exactly one service block must be set.",
+		CreateContext: resourceTrueNASAlertServiceCreate,
+		ReadContext:   resourceTrueNASAlertServiceRead,
+		UpdateContext: resourceTrueNASAlertServiceUpdate,
+		DeleteContext: resourceTrueNASAlertServiceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Description: "Alert service name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"type": &schema.Schema{
+				Description: "TrueNAS alert service type, eg. `Mail`, `Slack`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"level": &schema.Schema{
+				Description:  "Minimum alert level sent: `INFO`, `NOTICE`, `WARNING`, `ERROR`, `CRITICAL`, `ALERT` or `EMERGENCY`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "WARNING",
+				ValidateFunc: validation.StringInSlice(alertLevels, false),
+			},
+			"enabled": &schema.Schema{
+				Description: "`true` if alerts are sent",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"test_on_apply": &schema.Schema{
+				Description: "Send a test alert after creating or updating the service, fails if it cannot be sent",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"mail": &schema.Schema{
+				Description:  "Email, uses `truenas_mail_config` settings",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": &schema.Schema{
+							Description: "Recipient email address, root user email if empty",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"slack": &schema.Schema{
+				Description:  "Slack incoming webhook",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": &schema.Schema{
+							Description:  "Webhook URL",
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.IsURLWithHTTPS,
+						},
+					},
+				},
+			},
+			"pagerduty": &schema.Schema{
+				Description:  "PagerDuty",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_key": &schema.Schema{
+							Description: "Integration key",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"client_name": &schema.Schema{
+							Description: "Client name shown in PagerDuty",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"opsgenie": &schema.Schema{
+				Description:  "OpsGenie",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_key": &schema.Schema{
+							Description: "API key",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"api_url": &schema.Schema{
+							Description:  "API URL, eg. `https://api.eu.opsgenie.com`, default if empty",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsURLWithHTTPS,
+						},
+					},
+				},
+			},
+			"mattermost": &schema.Schema{
+				Description:  "Mattermost incoming webhook",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": &schema.Schema{
+							Description:  "Webhook URL",
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"username": &schema.Schema{
+							Description: "Username alerts are posted as",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"channel": &schema.Schema{
+							Description: "Channel, webhook default if empty",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"icon_url": &schema.Schema{
+							Description: "Icon URL",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"telegram": &schema.Schema{
+				Description:  "Telegram bot",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bot_token": &schema.Schema{
+							Description: "Bot token",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"chat_ids": &schema.Schema{
+							Description: "Chat IDs alerts are sent to",
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+						},
+					},
+				},
+			},
+			"snmp_trap": &schema.Schema{
+				Description:  "SNMP trap",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": &schema.Schema{
+							Description: "Trap receiver host",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"port": &schema.Schema{
+							Description:  "Trap receiver port",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      162,
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"v3": &schema.Schema{
+							Description: "Use SNMPv3",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"community": &schema.Schema{
+							Description: "SNMPv1/v2c community",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"v3_username": &schema.Schema{
+							Description: "SNMPv3 username",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"v3_authkey": &schema.Schema{
+							Description: "SNMPv3 authentication key",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"v3_privkey": &schema.Schema{
+							Description: "SNMPv3 privacy key",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"v3_authprotocol": &schema.Schema{
+							Description:  "SNMPv3 authentication protocol: `MD5`, `SHA`, `128SHA224`, `192SHA256`, `256SHA384`, `384SHA512`",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"MD5", "SHA", "128SHA224", "192SHA256", "256SHA384", "384SHA512"}, false),
+						},
+						"v3_privprotocol": &schema.Schema{
+							Description:  "SNMPv3 privacy protocol: `DES`, `3DESEDE`, `AESCFB128`, `AESCFB192`, `AESCFB256`, `AESBLUMENTHALCFB192`, `AESBLUMENTHALCFB256`",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"DES", "3DESEDE", "AESCFB128", "AESCFB192", "AESCFB256", "AESBLUMENTHALCFB192", "AESBLUMENTHALCFB256"}, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTrueNASAlertServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var service AlertService
+
+	if err := apiGet(ctx, m, "/alertservice/id/"+d.Id(), nil, &service); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS alert service (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting alert service: %s", err)
+	}
+
+	serviceType := service.Type
+
+	if serviceType == "" {
+		serviceType, _ = service.Attributes["type"].(string)
+	}
+
+	d.Set("name", service.Name)
+	d.Set("type", serviceType)
+	d.Set("level", service.Level)
+	d.Set("enabled", service.Enabled)
+
+	for block, t := range alertServiceTypes {
+		if t != serviceType {
+			d.Set(block, nil)
+			continue
+		}
+
+		blockSchema := resourceTrueNASAlertService().Schema[block].Elem.(*schema.Resource).Schema
+
+		if err := d.Set(block, flattenTypedAttributes(d, block, blockSchema, service.Attributes)); err != nil {
+			return diag.Errorf("error setting %s: %s", block, err)
+		}
+	}
+
+	return diags
+}
+
+func resourceTrueNASAlertServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input, err := expandAlertService(ctx, m, d)
+
+	if err != nil {
+		return diag.Errorf("error creating alert service: %s", err)
+	}
+
+	var service AlertService
+
+	if err := apiPost(ctx, m, "/alertservice", input, &service); err != nil {
+		return diag.Errorf("error creating alert service: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(service.Id))
+
+	if d.Get("test_on_apply").(bool) {
+		if err := testAlertService(ctx, m, input); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceTrueNASAlertServiceRead(ctx, d, m)
+}
+
+func resourceTrueNASAlertServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input, err := expandAlertService(ctx, m, d)
+
+	if err != nil {
+		return diag.Errorf("error updating alert service: %s", err)
+	}
+
+	if err := apiPut(ctx, m, "/alertservice/id/"+d.Id(), input, nil); err != nil {
+		return diag.Errorf("error updating alert service: %s", err)
+	}
+
+	if d.Get("test_on_apply").(bool) {
+		if err := testAlertService(ctx, m, input); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceTrueNASAlertServiceRead(ctx, d, m)
+}
+
+func resourceTrueNASAlertServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS alert service: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/alertservice/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting alert service: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS alert service (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func expandAlertService(ctx context.Context, m interface{}, d *schema.ResourceData) (*AlertService, error) {
+	serviceType, attributes := expandTypedAttributes(d, alertServiceTypes)
+
+	service := &AlertService{
+		Name:       d.Get("name").(string),
+		Attributes: attributes,
+		Level:      d.Get("level").(string),
+		Enabled:    d.Get("enabled").(bool),
+	}
+
+	version, err := getSystemVersion(ctx, m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if version.ScaleAtLeast(25, 4) {
+		service.Attributes["type"] = serviceType
+	} else {
+		service.Type = serviceType
+	}
+
+	return service, nil
+}
+
+// testAlertService sends test alert using service settings
+func testAlertService(ctx context.Context, m interface{}, service *AlertService) error {
+	var ok bool
+
+	log.Printf("[DEBUG] Sending TrueNAS test alert: %s", service.Name)
+
+	if err := apiPost(ctx, m, "/alertservice/test", service, &ok); err != nil {
+		return fmt.Errorf("error testing alert service: %s", err)
+	}
+
+	if !ok {
+		return fmt.Errorf("test alert could not be sent with alert service %s, check TrueNAS logs", service.Name)
+	}
+
+	return nil
+}
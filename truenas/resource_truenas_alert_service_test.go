@@ -0,0 +1,57 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccResourceTruenasAlertService_basic(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	name := fmt.Sprintf("%s-%s", testResourcePrefix, suffix)
+	resourceName := "truenas_alert_service.slack"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasAlertServiceConfig(name, "WARNING"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "type", "Slack"),
+					resource.TestCheckResourceAttr(resourceName, "level", "WARNING"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "slack.0.url", "https://hooks.slack.com/services/T000/B000/XXXX"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasAlertServiceConfig(name, "CRITICAL"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "level", "CRITICAL"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"test_on_apply"},
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasAlertServiceConfig(name string, level string) string {
+	return fmt.Sprintf(`
+		resource "truenas_alert_service" "slack" {
+			name = "%s"
+			level = "%s"
+			enabled = false
+
+			slack {
+				url = "https://hooks.slack.com/services/T000/B000/XXXX"
+			}
+		}
+	`, name, level)
+}
@@ -0,0 +1,322 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// APIKey is api_key middleware object, key is only returned on creation and reset
+type APIKey struct {
+	Id        int               `json:"id"`
+	Name      string            `json:"name"`
+	Key       string            `json:"key,omitempty"`
+	Username  *string           `json:"username,omitempty"`
+	CreatedAt *apiDate          `json:"created_at,omitempty"`
+	ExpiresAt *apiDate          `json:"expires_at,omitempty"`
+	Revoked   *bool             `json:"revoked,omitempty"`
+	Allowlist []APIKeyAllowlist `json:"allowlist,omitempty"`
+}
+
+type APIKeyAllowlist struct {
+	Method   string `json:"method"`
+	Resource string `json:"resource"`
+}
+
+// attributes available only on some TrueNAS versions, api keys are bound to users since SCALE 24.10, which dropped allowlists
+var apiKeyScaleAttributes = map[string][2]int{
+	"username":   {24, 10},
+	"expires_at": {24, 10},
+}
+
+func resourceTrueNASAPIKey() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates an API key. Generated key is only available after creation or reset, and is kept in state. Imported API keys have empty `key` until `reset_trigger` is changed.",
+		CreateContext: resourceTrueNASAPIKeyCreate,
+		ReadContext:   resourceTrueNASAPIKeyRead,
+		UpdateContext: resourceTrueNASAPIKeyUpdate,
+		DeleteContext: resourceTrueNASAPIKeyDelete,
+		CustomizeDiff: resourceTrueNASAPIKeyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Description: "API key name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"username": &schema.Schema{
+				Description: "User the API key authenticates as (TrueNAS SCALE 24.10 or newer)",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"expires_at": &schema.Schema{
+				Description:  "Expiration time in RFC 3339 format, eg. `2025-01-01T00:00:00Z` (TrueNAS SCALE 24.10 or newer)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"allowlist": &schema.Schema{
+				Description: "Restrict API key to specific methods and resources (not supported on TrueNAS SCALE 24.10 or newer)",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"method": &schema.Schema{
+							Description:  "HTTP method, `CALL` or `SUBSCRIBE` for websocket API, `*` for any",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"GET", "POST", "PUT", "DELETE", "CALL", "SUBSCRIBE", "*"}, false),
+						},
+						"resource": &schema.Schema{
+							Description: "Resource path or method name, eg. `/pool/dataset/` or `pool.dataset.query`, `*` for any",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"reset_trigger": &schema.Schema{
+				Description: "Arbitrary value, changing it resets the API key and generates new `key`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"key": &schema.Schema{
+				Description: "Generated API key",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"created_at": &schema.Schema{
+				Description: "Creation time in RFC 3339 format",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"revoked": &schema.Schema{
+				Description: "`true` if API key was revoked by TrueNAS, eg. when used over insecure transport",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceTrueNASAPIKeyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// reset revokes current key
+	if d.HasChange("reset_trigger") {
+		if err := d.SetNewComputed("key"); err != nil {
+			return err
+		}
+	}
+
+	config := d.GetRawConfig()
+
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	var configured []string
+
+	for attr := range apiKeyScaleAttributes {
+		if !config.GetAttr(attr).IsNull() {
+			configured = append(configured, attr)
+		}
+	}
+
+	allowlist := config.GetAttr("allowlist")
+	hasAllowlist := !allowlist.IsNull() && allowlist.IsKnown() && allowlist.LengthInt() > 0
+
+	if len(configured) == 0 && !hasAllowlist {
+		return nil
+	}
+
+	version, err := getSystemVersion(ctx, m)
+
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(configured)
+
+	for _, attr := range configured {
+		minVersion := apiKeyScaleAttributes[attr]
+
+		if !version.ScaleAtLeast(minVersion[0], minVersion[1]) {
+			return fmt.Errorf("%s requires TrueNAS SCALE %d.%02d or newer, got %s", attr, minVersion[0], minVersion[1], version.Raw)
+		}
+	}
+
+	if hasAllowlist && version.ScaleAtLeast(24, 10) {
+		return fmt.Errorf("allowlist is not supported on %s, API keys are restricted by user roles instead", version.Raw)
+	}
+
+	return nil
+}
+
+func resourceTrueNASAPIKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var key APIKey
+
+	if err := apiGet(ctx, m, "/api_key/id/"+d.Id(), nil, &key); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS API key (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting API key: %s", err)
+	}
+
+	d.Set("name", key.Name)
+	d.Set("created_at", flattenAPIDate(key.CreatedAt))
+
+	if key.Username != nil {
+		d.Set("username", *key.Username)
+	}
+
+	// keep configured format, server may normalize fractional seconds and timezone
+	if expiresAt, err := time.Parse(time.RFC3339, d.Get("expires_at").(string)); err != nil || key.ExpiresAt == nil || !expiresAt.Equal(key.ExpiresAt.Time()) {
+		d.Set("expires_at", flattenAPIDate(key.ExpiresAt))
+	}
+
+	if key.Revoked != nil {
+		d.Set("revoked", *key.Revoked)
+	}
+
+	if err := d.Set("allowlist", flattenAPIKeyAllowlist(key.Allowlist)); err != nil {
+		return diag.Errorf("error setting allowlist: %s", err)
+	}
+
+	return diags
+}
+
+func resourceTrueNASAPIKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input, err := expandAPIKey(d)
+
+	if err != nil {
+		return diag.Errorf("error creating API key: %s", err)
+	}
+
+	if username, ok := d.GetOk("username"); ok {
+		input["username"] = username.(string)
+	}
+
+	var key APIKey
+
+	if err := apiPost(ctx, m, "/api_key", input, &key); err != nil {
+		return diag.Errorf("error creating API key: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(key.Id))
+	d.Set("key", key.Key)
+
+	return resourceTrueNASAPIKeyRead(ctx, d, m)
+}
+
+func resourceTrueNASAPIKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input, err := expandAPIKey(d)
+
+	if err != nil {
+		return diag.Errorf("error updating API key: %s", err)
+	}
+
+	reset := d.HasChange("reset_trigger")
+
+	if reset {
+		input["reset"] = true
+	}
+
+	var key APIKey
+
+	if err := apiPut(ctx, m, "/api_key/id/"+d.Id(), input, &key); err != nil {
+		return diag.Errorf("error updating API key: %s", err)
+	}
+
+	if reset {
+		d.Set("key", key.Key)
+	}
+
+	return resourceTrueNASAPIKeyRead(ctx, d, m)
+}
+
+func resourceTrueNASAPIKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS API key: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/api_key/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting API key: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS API key (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func expandAPIKey(d *schema.ResourceData) (map[string]interface{}, error) {
+	input := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+
+	if d.HasChange("expires_at") || d.IsNewResource() {
+		if v, ok := d.GetOk("expires_at"); ok {
+			expiresAt, err := time.Parse(time.RFC3339, v.(string))
+
+			if err != nil {
+				return nil, err
+			}
+
+			input["expires_at"] = newAPIDate(expiresAt)
+		} else if !d.IsNewResource() {
+			// clear expiration
+			input["expires_at"] = nil
+		}
+	}
+
+	if v, ok := d.GetOk("allowlist"); ok {
+		input["allowlist"] = expandAPIKeyAllowlist(v.([]interface{}))
+	} else if d.HasChange("allowlist") {
+		input["allowlist"] = []APIKeyAllowlist{}
+	}
+
+	return input, nil
+}
+
+func expandAPIKeyAllowlist(l []interface{}) []APIKeyAllowlist {
+	res := make([]APIKeyAllowlist, len(l))
+
+	for i, v := range l {
+		entry := v.(map[string]interface{})
+
+		res[i] = APIKeyAllowlist{
+			Method:   entry["method"].(string),
+			Resource: entry["resource"].(string),
+		}
+	}
+
+	return res
+}
+
+func flattenAPIKeyAllowlist(l []APIKeyAllowlist) []interface{} {
+	res := make([]interface{}, len(l))
+
+	for i, entry := range l {
+		res[i] = map[string]interface{}{
+			"method":   entry.Method,
+			"resource": entry.Resource,
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,687 @@
+package truenas
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Certificate is certificate and certificateauthority middleware object
+type Certificate struct {
+	Id                    int                    `json:"id"`
+	Name                  string                 `json:"name"`
+	Certificate           *string                `json:"certificate"`
+	PrivateKey            *string                `json:"privatekey"`
+	CSR                   *string                `json:"CSR"`
+	KeyType               *string                `json:"key_type"`
+	KeyLength             *int                   `json:"key_length"`
+	ECCurve               *string                `json:"ec_curve"`
+	DigestAlgorithm       *string                `json:"digest_algorithm"`
+	Lifetime              *int                   `json:"lifetime"`
+	Country               *string                `json:"country"`
+	State                 *string                `json:"state"`
+	City                  *string                `json:"city"`
+	Organization          *string                `json:"organization"`
+	OrganizationalUnit    *string                `json:"organizational_unit"`
+	Email                 *string                `json:"email"`
+	Common                *string                `json:"common"`
+	SAN                   []string               `json:"san"`
+	SignedBy              map[string]interface{} `json:"signedby"`
+	ACMEURI               *string                `json:"acme_uri"`
+	DomainsAuthenticators map[string]interface{} `json:"domains_authenticators"`
+	RenewDays             *int                   `json:"renew_days"`
+	CertTypeExisting      bool                   `json:"cert_type_existing"`
+	CertTypeInternal      bool                   `json:"cert_type_internal"`
+	CertTypeCSR           bool                   `json:"cert_type_CSR"`
+	CATypeInternal        bool                   `json:"CA_type_internal"`
+	CATypeIntermediate    bool                   `json:"CA_type_intermediate"`
+	Revoked               bool                   `json:"revoked"`
+}
+
+var certificateNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// certificateCreateTypes maps certificate types to TrueNAS create types
+var certificateCreateTypes = map[string]string{
+	"IMPORTED": "CERTIFICATE_CREATE_IMPORTED",
+	"CSR":      "CERTIFICATE_CREATE_CSR",
+	"INTERNAL": "CERTIFICATE_CREATE_INTERNAL",
+	"ACME":     "CERTIFICATE_CREATE_ACME",
+}
+
+// attributes sent on creation by certificate type
+var certificateCreateAttributes = map[string][]string{
+	"IMPORTED": {"certificate", "private_key", "passphrase"},
+	"CSR":      {"key_type", "key_length", "ec_curve", "digest_algorithm", "country", "state", "city", "organization", "organizational_unit", "email", "common", "san"},
+	"INTERNAL": {"key_type", "key_length", "ec_curve", "digest_algorithm", "country", "state", "city", "organization", "organizational_unit", "email", "common", "san", "lifetime", "signed_by"},
+	"ACME":     {"csr_id", "acme_directory_uri", "tos", "dns_mapping", "renew_days"},
+}
+
+// API names of attributes that differ
+var certificateAPIAttributes = map[string]string{
+	"private_key": "privatekey",
+	"signed_by":   "signedby",
+}
+
+func resourceTrueNASCertificate() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates a certificate: imports existing certificate, creates a certificate signing request, an internal certificate signed by a certificate authority or requests a certificate from ACME server (eg. Let's Encrypt) using DNS authenticator",
+		CreateContext: resourceTrueNASCertificateCreate,
+		ReadContext:   resourceTrueNASCertificateRead,
+		UpdateContext: resourceTrueNASCertificateUpdate,
+		DeleteContext: resourceTrueNASCertificateDelete,
+		CustomizeDiff: certificateCustomizeDiff(certificateCreateAttributes, map[string][]string{
+			"IMPORTED": {"certificate", "private_key"},
+			"INTERNAL": {"signed_by"},
+			"ACME":     {"csr_id", "acme_directory_uri", "dns_mapping"},
+		}),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: certificateSchema(map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Description:  "Certificate type: `IMPORTED`, `CSR`, `INTERNAL` (signed by `signed_by` CA) or `ACME` (signed by ACME server from `csr_id`)",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(sortedMapKeys(certificateCreateTypes), false),
+			},
+			"csr": &schema.Schema{
+				Description: "Certificate signing request in PEM format, for `CSR` type",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"csr_id": &schema.Schema{
+				Description: "ID of `CSR` certificate to request from ACME server, for `ACME` type",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"acme_directory_uri": &schema.Schema{
+				Description:  "ACME server directory URI, eg. `https://acme-v02.api.letsencrypt.org/directory`, for `ACME` type",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+			},
+			"tos": &schema.Schema{
+				Description: "Accept ACME server terms of service, for `ACME` type",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"dns_mapping": &schema.Schema{
+				Description: "ACME DNS authenticator ID by domain, must include every domain of the CSR, for `ACME` type",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"renew_days": &schema.Schema{
+				Description:  "Renew ACME certificate this many days before expiry, for `ACME` type",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 30),
+			},
+		}),
+	}
+}
+
+// certificateSchema returns attributes shared by certificates and certificate authorities, extended by s
+func certificateSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	res := map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Description:  "Name, letters, numbers, `-` and `_` only",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringMatch(certificateNameRegexp, "name must only contain letters, numbers, - and _"),
+		},
+		"certificate": &schema.Schema{
+			Description:      "Certificate in PEM format, required for imported certificates, may include the chain",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: suppressPEMDiff,
+		},
+		"private_key": &schema.Schema{
+			Description:      "Private key in PEM format, generated if not imported",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ForceNew:         true,
+			Sensitive:        true,
+			DiffSuppressFunc: suppressPEMDiff,
+		},
+		"passphrase": &schema.Schema{
+			Description: "Passphrase of imported private key",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Sensitive:   true,
+		},
+		"key_type": &schema.Schema{
+			Description:  "Key type: `RSA` or `EC`",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"RSA", "EC"}, false),
+		},
+		"key_length": &schema.Schema{
+			Description:  "RSA key length: `1024`, `2048` or `4096`",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntInSlice([]int{1024, 2048, 4096}),
+		},
+		"ec_curve": &schema.Schema{
+			Description:  "EC key curve: `SECP256R1`, `SECP384R1`, `SECP521R1` or `ed25519`",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"SECP256R1", "SECP384R1", "SECP521R1", "ed25519"}, false),
+		},
+		"digest_algorithm": &schema.Schema{
+			Description:  "Digest algorithm: `SHA1`, `SHA224`, `SHA256`, `SHA384` or `SHA512`",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"SHA1", "SHA224", "SHA256", "SHA384", "SHA512"}, false),
+		},
+		"lifetime": &schema.Schema{
+			Description:  "Lifetime in days",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"country": &schema.Schema{
+			Description: "Subject country code, eg. `US`",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"state": &schema.Schema{
+			Description: "Subject state or province",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"city": &schema.Schema{
+			Description: "Subject locality",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"organization": &schema.Schema{
+			Description: "Subject organization",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"organizational_unit": &schema.Schema{
+			Description: "Subject organizational unit",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"email": &schema.Schema{
+			Description: "Subject email address",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"common": &schema.Schema{
+			Description: "Subject common name",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"san": &schema.Schema{
+			Description: "Requested subject alternative names, eg. `nas.example.com`, `192.168.1.10`",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"signed_by": &schema.Schema{
+			Description: "ID of signing certificate authority",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		"revoked": &schema.Schema{
+			Description: "Revoke certificate, only certificates signed by internal certificate authority can be revoked",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+	}
+
+	for k, v := range certificateDetailsSchema() {
+		res[k] = v
+	}
+
+	for k, v := range s {
+		res[k] = v
+	}
+
+	return res
+}
+
+// certificateDetailsSchema returns attributes parsed from certificate
+func certificateDetailsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"not_before": &schema.Schema{
+			Description: "Start of validity period in RFC 3339 format",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"expires_at": &schema.Schema{
+			Description: "End of validity period in RFC 3339 format",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"expired": &schema.Schema{
+			Description: "`true` if certificate has expired",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"fingerprint": &schema.Schema{
+			Description: "SHA-256 fingerprint, colon separated hex",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"serial": &schema.Schema{
+			Description: "Serial number, hex",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"issuer": &schema.Schema{
+			Description: "Issuer distinguished name",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"subject": &schema.Schema{
+			Description: "Subject distinguished name",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"subject_alternative_names": &schema.Schema{
+			Description: "Subject alternative names: DNS names, IP addresses, email addresses and URIs",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func resourceTrueNASCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var certificate Certificate
+
+	if err := apiGet(ctx, m, "/certificate/id/"+d.Id(), nil, &certificate); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS certificate (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting certificate: %s", err)
+	}
+
+	if err := flattenCertificate(d, certificate); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("type", flattenCertificateType(certificate))
+
+	if certificate.CSR != nil {
+		d.Set("csr", *certificate.CSR)
+	}
+
+	if certificate.ACMEURI != nil {
+		d.Set("acme_directory_uri", *certificate.ACMEURI)
+	}
+
+	if certificate.RenewDays != nil {
+		d.Set("renew_days", *certificate.RenewDays)
+	}
+
+	if len(certificate.DomainsAuthenticators) > 0 {
+		mapping := map[string]interface{}{}
+
+		for domain, id := range certificate.DomainsAuthenticators {
+			if f, ok := id.(float64); ok {
+				mapping[domain] = int(f)
+			}
+		}
+
+		d.Set("dns_mapping", mapping)
+	}
+
+	return diags
+}
+
+func resourceTrueNASCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	certificateType := d.Get("type").(string)
+
+	input := expandCertificate(d, certificateCreateAttributes[certificateType])
+	input["create_type"] = certificateCreateTypes[certificateType]
+
+	var certificate Certificate
+
+	if err := apiPostJob(ctx, m, "/certificate", input, &certificate); err != nil {
+		return diag.Errorf("error creating certificate: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(certificate.Id))
+
+	if d.Get("revoked").(bool) {
+		return resourceTrueNASCertificateUpdate(ctx, d, m)
+	}
+
+	return resourceTrueNASCertificateRead(ctx, d, m)
+}
+
+func resourceTrueNASCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+
+	// revoking is irreversible and only supported by internal certificates
+	if d.Get("revoked").(bool) {
+		input["revoked"] = true
+	}
+
+	if d.Get("type").(string) == "ACME" && d.HasChange("renew_days") {
+		input["renew_days"] = d.Get("renew_days").(int)
+	}
+
+	if err := apiPutJob(ctx, m, "/certificate/id/"+d.Id(), input, nil); err != nil {
+		return diag.Errorf("error updating certificate: %s", err)
+	}
+
+	return resourceTrueNASCertificateRead(ctx, d, m)
+}
+
+func resourceTrueNASCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS certificate: %s", d.Id())
+
+	if err := apiDeleteJob(ctx, m, "/certificate/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting certificate: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS certificate (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+// certificateCustomizeDiff validates attributes by type, createAttributes are allowed and required attributes are required for each type
+func certificateCustomizeDiff(createAttributes map[string][]string, required map[string][]string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if old, _ := d.GetChange("revoked"); old.(bool) && !d.Get("revoked").(bool) {
+			return fmt.Errorf("revoked certificate cannot be restored")
+		}
+
+		config := d.GetRawConfig()
+
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+
+		certificateType := d.Get("type").(string)
+
+		for _, attr := range required[certificateType] {
+			if config.GetAttr(attr).IsNull() {
+				return fmt.Errorf("%s is required for %s type", attr, certificateType)
+			}
+		}
+
+		// attributes of other types would be silently ignored
+		allowed := map[string]bool{}
+
+		for _, attr := range createAttributes[certificateType] {
+			allowed[attr] = true
+		}
+
+		for _, attrs := range createAttributes {
+			for _, attr := range attrs {
+				if !allowed[attr] && !config.GetAttr(attr).IsNull() {
+					return fmt.Errorf("%s is not supported for %s type", attr, certificateType)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// expandCertificate returns create payload with configured attributes from attrs
+func expandCertificate(d *schema.ResourceData, attrs []string) map[string]interface{} {
+	input := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+
+	for _, attr := range attrs {
+		if !isConfigured(d, attr) {
+			continue
+		}
+
+		name := attr
+
+		if apiName, ok := certificateAPIAttributes[attr]; ok {
+			name = apiName
+		}
+
+		switch v := d.Get(attr).(type) {
+		case []interface{}:
+			input[name] = expandStrings(v)
+		default:
+			input[name] = v
+		}
+	}
+
+	return input
+}
+
+// flattenCertificate sets attributes shared by certificates and certificate authorities
+// flattenCertificateSAN strips type prefixes from API subject alternative names, eg. `DNS:` or `IP Address:`,
+// configured order is kept when names are the same
+func flattenCertificateSAN(configured []interface{}, san []string) []interface{} {
+	res := make([]interface{}, 0, len(san))
+	names := make(map[string]int, len(san))
+
+	for _, name := range san {
+		if i := strings.Index(name, ":"); i != -1 && net.ParseIP(name) == nil {
+			name = strings.TrimSpace(name[i+1:])
+		}
+
+		res = append(res, name)
+		names[name]++
+	}
+
+	if len(configured) != len(res) {
+		return res
+	}
+
+	for _, name := range configured {
+		if names[name.(string)] == 0 {
+			return res
+		}
+		names[name.(string)]--
+	}
+
+	return configured
+}
+
+func flattenCertificate(d *schema.ResourceData, certificate Certificate) error {
+	d.Set("name", certificate.Name)
+	d.Set("revoked", certificate.Revoked)
+
+	strs := map[string]*string{
+		"certificate":         certificate.Certificate,
+		"private_key":         certificate.PrivateKey,
+		"key_type":            certificate.KeyType,
+		"ec_curve":            certificate.ECCurve,
+		"digest_algorithm":    certificate.DigestAlgorithm,
+		"country":             certificate.Country,
+		"state":               certificate.State,
+		"city":                certificate.City,
+		"organization":        certificate.Organization,
+		"organizational_unit": certificate.OrganizationalUnit,
+		"email":               certificate.Email,
+		"common":              certificate.Common,
+	}
+
+	for attr, v := range strs {
+		if v != nil {
+			d.Set(attr, *v)
+		}
+	}
+
+	if certificate.KeyLength != nil {
+		d.Set("key_length", *certificate.KeyLength)
+	}
+
+	if certificate.Lifetime != nil {
+		d.Set("lifetime", *certificate.Lifetime)
+	}
+
+	if id, ok := certificate.SignedBy["id"].(float64); ok {
+		d.Set("signed_by", int(id))
+	}
+
+	if certificate.SAN != nil {
+		if err := d.Set("san", flattenCertificateSAN(d.Get("san").([]interface{}), certificate.SAN)); err != nil {
+			return fmt.Errorf("error setting san: %s", err)
+		}
+	}
+
+	if certificate.Certificate == nil || *certificate.Certificate == "" {
+		return nil
+	}
+
+	details, err := parseCertificateDetails(*certificate.Certificate)
+
+	if err != nil {
+		log.Printf("[WARN] Unable to parse TrueNAS certificate (%s): %s", d.Id(), err)
+		return nil
+	}
+
+	for attr, v := range details {
+		if err := d.Set(attr, v); err != nil {
+			return fmt.Errorf("error setting %s: %s", attr, err)
+		}
+	}
+
+	return nil
+}
+
+// flattenCertificateType returns certificate type, ACME certificates are stored as existing certificates
+func flattenCertificateType(certificate Certificate) string {
+	switch {
+	case certificate.ACMEURI != nil && *certificate.ACMEURI != "":
+		return "ACME"
+	case certificate.CertTypeCSR:
+		return "CSR"
+	case certificate.CertTypeInternal:
+		return "INTERNAL"
+	default:
+		return "IMPORTED"
+	}
+}
+
+// parseCertificateDetails parses first certificate of PEM chain, returns attributes of certificateDetailsSchema
+func parseCertificateDetails(encoded string) (map[string]interface{}, error) {
+	block, _ := pem.Decode([]byte(encoded))
+
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no certificate found in PEM data")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := make([]string, len(sum))
+
+	for i, b := range sum {
+		fingerprint[i] = fmt.Sprintf("%02X", b)
+	}
+
+	sans := []interface{}{}
+
+	for _, name := range cert.DNSNames {
+		sans = append(sans, name)
+	}
+
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	for _, email := range cert.EmailAddresses {
+		sans = append(sans, email)
+	}
+
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+
+	return map[string]interface{}{
+		"not_before":                cert.NotBefore.UTC().Format(time.RFC3339),
+		"expires_at":                cert.NotAfter.UTC().Format(time.RFC3339),
+		"expired":                   time.Now().After(cert.NotAfter),
+		"fingerprint":               strings.Join(fingerprint, ":"),
+		"serial":                    fmt.Sprintf("%X", cert.SerialNumber),
+		"issuer":                    cert.Issuer.String(),
+		"subject":                   cert.Subject.String(),
+		"subject_alternative_names": sans,
+	}, nil
+}
+
+// suppressPEMDiff ignores surrounding whitespace, TrueNAS normalizes stored PEM data
+func suppressPEMDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
@@ -0,0 +1,134 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strconv"
+)
+
+// certificateAuthorityCreateTypes maps certificate authority types to TrueNAS create types
+var certificateAuthorityCreateTypes = map[string]string{
+	"IMPORTED":     "CA_CREATE_IMPORTED",
+	"INTERNAL":     "CA_CREATE_INTERNAL",
+	"INTERMEDIATE": "CA_CREATE_INTERMEDIATE",
+}
+
+// attributes sent on creation by certificate authority type
+var certificateAuthorityCreateAttributes = map[string][]string{
+	"IMPORTED":     {"certificate", "private_key", "passphrase"},
+	"INTERNAL":     {"key_type", "key_length", "ec_curve", "digest_algorithm", "country", "state", "city", "organization", "organizational_unit", "email", "common", "san", "lifetime"},
+	"INTERMEDIATE": {"key_type", "key_length", "ec_curve", "digest_algorithm", "country", "state", "city", "organization", "organizational_unit", "email", "common", "san", "lifetime", "signed_by"},
+}
+
+func resourceTrueNASCertificateAuthority() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates a certificate authority: imports existing CA, creates internal root CA or intermediate CA signed by another certificate authority",
+		CreateContext: resourceTrueNASCertificateAuthorityCreate,
+		ReadContext:   resourceTrueNASCertificateAuthorityRead,
+		UpdateContext: resourceTrueNASCertificateAuthorityUpdate,
+		DeleteContext: resourceTrueNASCertificateAuthorityDelete,
+		CustomizeDiff: certificateCustomizeDiff(certificateAuthorityCreateAttributes, map[string][]string{
+			"IMPORTED":     {"certificate"},
+			"INTERMEDIATE": {"signed_by"},
+		}),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: certificateSchema(map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Description:  "Certificate authority type: `IMPORTED`, `INTERNAL` or `INTERMEDIATE` (signed by `signed_by` CA)",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(sortedMapKeys(certificateAuthorityCreateTypes), false),
+			},
+		}),
+	}
+}
+
+func resourceTrueNASCertificateAuthorityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var ca Certificate
+
+	if err := apiGet(ctx, m, "/certificateauthority/id/"+d.Id(), nil, &ca); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS certificate authority (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting certificate authority: %s", err)
+	}
+
+	if err := flattenCertificate(d, ca); err != nil {
+		return diag.FromErr(err)
+	}
+
+	switch {
+	case ca.CATypeIntermediate:
+		d.Set("type", "INTERMEDIATE")
+	case ca.CATypeInternal:
+		d.Set("type", "INTERNAL")
+	default:
+		d.Set("type", "IMPORTED")
+	}
+
+	return diags
+}
+
+func resourceTrueNASCertificateAuthorityCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	caType := d.Get("type").(string)
+
+	input := expandCertificate(d, certificateAuthorityCreateAttributes[caType])
+	input["create_type"] = certificateAuthorityCreateTypes[caType]
+
+	var ca Certificate
+
+	if err := apiPost(ctx, m, "/certificateauthority", input, &ca); err != nil {
+		return diag.Errorf("error creating certificate authority: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(ca.Id))
+
+	if d.Get("revoked").(bool) {
+		return resourceTrueNASCertificateAuthorityUpdate(ctx, d, m)
+	}
+
+	return resourceTrueNASCertificateAuthorityRead(ctx, d, m)
+}
+
+func resourceTrueNASCertificateAuthorityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+
+	// revoking CA revokes every certificate it signed, irreversible
+	if d.Get("revoked").(bool) {
+		input["revoked"] = true
+	}
+
+	if err := apiPut(ctx, m, "/certificateauthority/id/"+d.Id(), input, nil); err != nil {
+		return diag.Errorf("error updating certificate authority: %s", err)
+	}
+
+	return resourceTrueNASCertificateAuthorityRead(ctx, d, m)
+}
+
+func resourceTrueNASCertificateAuthorityDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS certificate authority: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/certificateauthority/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting certificate authority: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS certificate authority (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
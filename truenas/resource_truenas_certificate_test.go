@@ -0,0 +1,140 @@
+package truenas
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAccResourceTruenasCertificate_internal(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	name := fmt.Sprintf("%s-%s", testResourcePrefix, suffix)
+	resourceName := "truenas_certificate.cert"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasCertificateConfig(name, "cert"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("truenas_certificate_authority.ca", "type", "INTERNAL"),
+					resource.TestCheckResourceAttr(resourceName, "type", "INTERNAL"),
+					resource.TestCheckResourceAttr(resourceName, "common", "nas.example.com"),
+					resource.TestCheckResourceAttrPair(resourceName, "signed_by", "truenas_certificate_authority.ca", "id"),
+					resource.TestCheckResourceAttr(resourceName, "subject_alternative_names.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "expires_at"),
+					resource.TestCheckResourceAttrSet(resourceName, "fingerprint"),
+					resource.TestCheckResourceAttr(resourceName, "expired", "false"),
+					resource.TestCheckResourceAttr("data.truenas_certificates.certs", "certificates.#", "1"),
+					resource.TestCheckResourceAttrPair("data.truenas_certificates.certs", "certificates.0.fingerprint", resourceName, "fingerprint"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasCertificateConfig(name, "renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name+"-renamed"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasCertificateConfig(name string, certName string) string {
+	return fmt.Sprintf(`
+		resource "truenas_certificate_authority" "ca" {
+			name = "%[1]s-ca"
+			type = "INTERNAL"
+			common = "Test CA"
+			country = "US"
+			state = "California"
+			city = "San Jose"
+			organization = "Example"
+			email = "admin@example.com"
+			san = ["ca.example.com"]
+		}
+
+		resource "truenas_certificate" "cert" {
+			name = "%[1]s-%[2]s"
+			type = "INTERNAL"
+			signed_by = truenas_certificate_authority.ca.id
+			common = "nas.example.com"
+			country = "US"
+			state = "California"
+			city = "San Jose"
+			organization = "Example"
+			email = "admin@example.com"
+			san = ["nas.example.com", "192.168.1.10"]
+			lifetime = 365
+		}
+
+		data "truenas_certificates" "certs" {
+			name_regex = "^${truenas_certificate.cert.name}$"
+		}
+	`, name, certName)
+}
+
+func TestParseCertificateDetails(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(255),
+		Subject:      pkix.Name{CommonName: "nas.example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		DNSNames:     []string{"nas.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("192.168.1.10")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	encoded := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	details, err := parseCertificateDetails(encoded)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2024-01-01T00:00:00Z", details["not_before"])
+	assert.Equal(t, "2025-01-01T00:00:00Z", details["expires_at"])
+	assert.Equal(t, true, details["expired"])
+	assert.Equal(t, "FF", details["serial"])
+	assert.Equal(t, "CN=nas.example.com", details["subject"])
+	assert.Equal(t, []interface{}{"nas.example.com", "192.168.1.10"}, details["subject_alternative_names"])
+	assert.Len(t, strings.Split(details["fingerprint"].(string), ":"), 32)
+
+	_, err = parseCertificateDetails("not a certificate")
+	assert.Error(t, err)
+}
+
+func TestFlattenCertificateSAN(t *testing.T) {
+	san := []string{"DNS:nas.example.com", "IP Address:192.168.1.10", "IP:2001:db8::10"}
+
+	assert.Equal(t, []interface{}{"nas.example.com", "192.168.1.10", "2001:db8::10"}, flattenCertificateSAN(nil, san))
+
+	// configured order is kept
+	configured := []interface{}{"2001:db8::10", "192.168.1.10", "nas.example.com"}
+	assert.Equal(t, configured, flattenCertificateSAN(configured, san))
+
+	assert.Equal(t, []interface{}{"nas.example.com"}, flattenCertificateSAN([]interface{}{"other.example.com"}, []string{"nas.example.com"}))
+}
@@ -0,0 +1,419 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strconv"
+)
+
+// CloudCredential is cloudsync.credentials middleware object,
+// provider is a string and attributes are separate before SCALE 25.04, provider object includes attributes since
+type CloudCredential struct {
+	Id         int                    `json:"id,omitempty"`
+	Name       string                 `json:"name"`
+	Provider   interface{}            `json:"provider"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// cloudCredentialProviders maps attribute blocks to TrueNAS provider types
+var cloudCredentialProviders = map[string]string{
+	"s3":     "S3",
+	"b2":     "B2",
+	"azure":  "AZUREBLOB",
+	"gcs":    "GOOGLE_CLOUD_STORAGE",
+	"sftp":   "SFTP",
+	"webdav": "WEBDAV",
+}
+
+func resourceTrueNASCloudCredential() *schema.Resource {
+	blocks := sortedMapKeys(cloudCredentialProviders)
+
+	return &schema.Resource{
+		Description:   "Creates cloud credentials used by cloud sync tasks. Exactly one provider block must be set.",
+		CreateContext: resourceTrueNASCloudCredentialCreate,
+		ReadContext:   resourceTrueNASCloudCredentialRead,
+		UpdateContext: resourceTrueNASCloudCredentialUpdate,
+		DeleteContext: resourceTrueNASCloudCredentialDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Description: "Credential name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"provider_type": &schema.Schema{
+				Description: "TrueNAS provider type, eg. `S3`, `B2`, `AZUREBLOB`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"verify": &schema.Schema{
+				Description: "Verify credentials with the provider before saving",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"s3": &schema.Schema{
+				Description:  "Amazon S3 or S3 compatible storage",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"access_key_id": &schema.Schema{
+							Description: "Access key ID",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"secret_access_key": &schema.Schema{
+							Description: "Secret access key",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"endpoint": &schema.Schema{
+							Description: "Endpoint URL for S3 compatible storage, leave empty for Amazon S3",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"region": &schema.Schema{
+							Description: "Region, detected automatically if empty",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"skip_region": &schema.Schema{
+							Description: "Skip automatic region detection, required by some S3 compatible storage",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"signatures_v2": &schema.Schema{
+							Description: "Use v2 signatures, required by some S3 compatible storage",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
+			"b2": &schema.Schema{
+				Description:  "Backblaze B2",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account": &schema.Schema{
+							Description: "Account ID or application key ID",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"key": &schema.Schema{
+							Description: "Application key",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"azure": &schema.Schema{
+				Description:  "Microsoft Azure Blob Storage",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account": &schema.Schema{
+							Description: "Storage account name",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"key": &schema.Schema{
+							Description: "Storage account key",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"endpoint": &schema.Schema{
+							Description: "Custom endpoint, leave empty for default",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"gcs": &schema.Schema{
+				Description:  "Google Cloud Storage",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_account_credentials": &schema.Schema{
+							Description:  "Service account JSON key",
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsJSON,
+						},
+					},
+				},
+			},
+			"sftp": &schema.Schema{
+				Description:  "SFTP server",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": &schema.Schema{
+							Description: "SSH host",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"port": &schema.Schema{
+							Description:  "SSH port",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      22,
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"user": &schema.Schema{
+							Description: "SSH username",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"pass": &schema.Schema{
+							Description: "SSH password",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"private_key": &schema.Schema{
+							Description: "SSH keypair (keychain credential) ID",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"webdav": &schema.Schema{
+				Description:  "WebDAV server",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: blocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": &schema.Schema{
+							Description:  "WebDAV URL",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"vendor": &schema.Schema{
+							Description:  "WebDAV vendor: `NEXTCLOUD`, `OWNCLOUD`, `SHAREPOINT`, `OTHER`",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "OTHER",
+							ValidateFunc: validation.StringInSlice([]string{"NEXTCLOUD", "OWNCLOUD", "SHAREPOINT", "OTHER"}, false),
+						},
+						"user": &schema.Schema{
+							Description: "Username",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"pass": &schema.Schema{
+							Description: "Password",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTrueNASCloudCredentialRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var credential CloudCredential
+
+	if err := apiGet(ctx, m, "/cloudsync/credentials/id/"+d.Id(), nil, &credential); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS cloud credential (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting cloud credential: %s", err)
+	}
+
+	providerType, attributes := flattenCloudCredentialProvider(credential)
+
+	d.Set("name", credential.Name)
+	d.Set("provider_type", providerType)
+
+	for block, p := range cloudCredentialProviders {
+		if p != providerType {
+			d.Set(block, nil)
+			continue
+		}
+
+		blockSchema := resourceTrueNASCloudCredential().Schema[block].Elem.(*schema.Resource).Schema
+
+		if err := d.Set(block, flattenTypedAttributes(d, block, blockSchema, attributes)); err != nil {
+			return diag.Errorf("error setting %s: %s", block, err)
+		}
+	}
+
+	return diags
+}
+
+func resourceTrueNASCloudCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input, err := expandCloudCredential(ctx, m, d)
+
+	if err != nil {
+		return diag.Errorf("error creating cloud credential: %s", err)
+	}
+
+	var credential CloudCredential
+
+	if err := apiPost(ctx, m, "/cloudsync/credentials", input, &credential); err != nil {
+		return diag.Errorf("error creating cloud credential: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(credential.Id))
+
+	return resourceTrueNASCloudCredentialRead(ctx, d, m)
+}
+
+func resourceTrueNASCloudCredentialUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input, err := expandCloudCredential(ctx, m, d)
+
+	if err != nil {
+		return diag.Errorf("error updating cloud credential: %s", err)
+	}
+
+	if err := apiPut(ctx, m, "/cloudsync/credentials/id/"+d.Id(), input, nil); err != nil {
+		return diag.Errorf("error updating cloud credential: %s", err)
+	}
+
+	return resourceTrueNASCloudCredentialRead(ctx, d, m)
+}
+
+func resourceTrueNASCloudCredentialDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS cloud credential: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/cloudsync/credentials/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting cloud credential: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS cloud credential (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+// expandCloudCredential builds payload in the format of connected TrueNAS version, verifying credentials if requested
+func expandCloudCredential(ctx context.Context, m interface{}, d *schema.ResourceData) (*CloudCredential, error) {
+	providerType, attributes := expandTypedAttributes(d, cloudCredentialProviders)
+
+	version, err := getSystemVersion(ctx, m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var credential *CloudCredential
+
+	if version.ScaleAtLeast(25, 4) {
+		provider := map[string]interface{}{"type": providerType}
+
+		for k, v := range attributes {
+			provider[k] = v
+		}
+
+		credential = &CloudCredential{
+			Name:     d.Get("name").(string),
+			Provider: provider,
+		}
+	} else {
+		credential = &CloudCredential{
+			Name:       d.Get("name").(string),
+			Provider:   providerType,
+			Attributes: attributes,
+		}
+	}
+
+	if d.Get("verify").(bool) {
+		if err := verifyCloudCredential(ctx, m, credential); err != nil {
+			return nil, err
+		}
+	}
+
+	return credential, nil
+}
+
+func verifyCloudCredential(ctx context.Context, m interface{}, credential *CloudCredential) error {
+	var result struct {
+		Valid   bool   `json:"valid"`
+		Error   string `json:"error"`
+		Excerpt string `json:"excerpt"`
+	}
+
+	input := map[string]interface{}{
+		"provider": credential.Provider,
+	}
+
+	if credential.Attributes != nil {
+		input["attributes"] = credential.Attributes
+	}
+
+	log.Printf("[DEBUG] Verifying TrueNAS cloud credential: %s", credential.Name)
+
+	if err := apiPost(ctx, m, "/cloudsync/credentials/verify", input, &result); err != nil {
+		return fmt.Errorf("error verifying credentials: %s", err)
+	}
+
+	if !result.Valid {
+		return fmt.Errorf("credentials verification failed: %s\n%s", result.Error, result.Excerpt)
+	}
+
+	return nil
+}
+
+// flattenCloudCredentialProvider returns provider type and attributes for both API formats
+func flattenCloudCredentialProvider(credential CloudCredential) (string, map[string]interface{}) {
+	if provider, ok := credential.Provider.(map[string]interface{}); ok {
+		providerType, _ := provider["type"].(string)
+		attributes := map[string]interface{}{}
+
+		for k, v := range provider {
+			if k != "type" {
+				attributes[k] = v
+			}
+		}
+
+		return providerType, attributes
+	}
+
+	providerType, _ := credential.Provider.(string)
+
+	return providerType, credential.Attributes
+}
@@ -0,0 +1,77 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAccResourceTruenasCloudCredential_basic(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	name := fmt.Sprintf("%s-%s", testResourcePrefix, suffix)
+	resourceName := "truenas_cloud_credential.s3"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasCloudCredentialConfig(name, "us-east-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "provider_type", "S3"),
+					resource.TestCheckResourceAttr(resourceName, "s3.0.access_key_id", "AKIAEXAMPLE"),
+					resource.TestCheckResourceAttr(resourceName, "s3.0.region", "us-east-1"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasCloudCredentialConfig(name, "eu-west-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "s3.0.region", "eu-west-1"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"verify"},
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasCloudCredentialConfig(name string, region string) string {
+	return fmt.Sprintf(`
+		resource "truenas_cloud_credential" "s3" {
+			name = "%s"
+
+			s3 {
+				access_key_id = "AKIAEXAMPLE"
+				secret_access_key = "secret"
+				endpoint = "https://s3.example.com"
+				region = "%s"
+				skip_region = true
+			}
+		}
+	`, name, region)
+}
+
+func TestFlattenCloudCredentialProvider(t *testing.T) {
+	// before SCALE 25.04
+	providerType, attributes := flattenCloudCredentialProvider(CloudCredential{
+		Provider:   "B2",
+		Attributes: map[string]interface{}{"account": "acc", "key": "secret"},
+	})
+
+	assert.Equal(t, "B2", providerType)
+	assert.Equal(t, map[string]interface{}{"account": "acc", "key": "secret"}, attributes)
+
+	providerType, attributes = flattenCloudCredentialProvider(CloudCredential{
+		Provider: map[string]interface{}{"type": "B2", "account": "acc", "key": "secret"},
+	})
+
+	assert.Equal(t, "B2", providerType)
+	assert.Equal(t, map[string]interface{}{"account": "acc", "key": "secret"}, attributes)
+}
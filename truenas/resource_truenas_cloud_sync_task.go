@@ -0,0 +1,378 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"regexp"
+	"strconv"
+)
+
+// CloudSyncTask is cloudsync middleware object, credentials are expanded to credential object in responses
+type CloudSyncTask struct {
+	Id                 int                    `json:"id,omitempty"`
+	Description        string                 `json:"description"`
+	Direction          string                 `json:"direction"`
+	TransferMode       string                 `json:"transfer_mode"`
+	Path               string                 `json:"path"`
+	Credentials        interface{}            `json:"credentials"`
+	Attributes         map[string]interface{} `json:"attributes"`
+	Schedule           *api.CronJobSchedule   `json:"schedule,omitempty"`
+	Enabled            bool                   `json:"enabled"`
+	Snapshot           bool                   `json:"snapshot"`
+	FollowSymlinks     bool                   `json:"follow_symlinks"`
+	Transfers          *int                   `json:"transfers"`
+	Encryption         bool                   `json:"encryption"`
+	FilenameEncryption bool                   `json:"filename_encryption"`
+	EncryptionPassword string                 `json:"encryption_password"`
+	EncryptionSalt     string                 `json:"encryption_salt"`
+	BWLimit            []CloudSyncBWLimit     `json:"bwlimit"`
+	Exclude            []string               `json:"exclude"`
+	PreScript          string                 `json:"pre_script"`
+	PostScript         string                 `json:"post_script"`
+}
+
+// CloudSyncBWLimit is bandwidth limit starting at time of day, nil bandwidth is unlimited
+type CloudSyncBWLimit struct {
+	Time      string `json:"time"`
+	Bandwidth *int   `json:"bandwidth"`
+}
+
+var bwLimitTimeRegexp = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`)
+
+func resourceTrueNASCloudSyncTask() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates a cloud sync task, which transfers data between a local path and cloud storage on schedule",
+		CreateContext: resourceTrueNASCloudSyncTaskCreate,
+		ReadContext:   resourceTrueNASCloudSyncTaskRead,
+		UpdateContext: resourceTrueNASCloudSyncTaskUpdate,
+		DeleteContext: resourceTrueNASCloudSyncTaskDelete,
+		CustomizeDiff: resourceTrueNASCloudSyncTaskCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"description": &schema.Schema{
+				Description: "Task description",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"direction": &schema.Schema{
+				Description:  "`PUSH` uploads local path to cloud, `PULL` downloads to local path",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PUSH", "PULL"}, false),
+			},
+			"transfer_mode": &schema.Schema{
+				Description:  "`SYNC` makes destination identical to source (deletes extra files), `COPY` copies new and changed files, `MOVE` copies files and deletes them from source",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"SYNC", "COPY", "MOVE"}, false),
+			},
+			"path": &schema.Schema{
+				Description: "Local path, eg. `/mnt/tank/data`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"credentials": &schema.Schema{
+				Description: "Cloud credential ID",
+				Type:        schema.TypeInt,
+				Required:    true,
+			},
+			"bucket": &schema.Schema{
+				Description: "Bucket or container name, not used by SFTP and WebDAV",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"folder": &schema.Schema{
+				Description: "Folder in the bucket or on the remote server",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"enabled": &schema.Schema{
+				Description: "`true` if task runs on schedule",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"snapshot": &schema.Schema{
+				Description: "Take a snapshot of the dataset before a `PUSH` and transfer it instead of live data",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"follow_symlinks": &schema.Schema{
+				Description: "Follow symlinks and copy the items they point to",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"transfers": &schema.Schema{
+				Description:  "Number of simultaneous file transfers, provider default if not set",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"encryption": &schema.Schema{
+				Description: "Encrypt files before transfer and store them encrypted on the remote",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"filename_encryption": &schema.Schema{
+				Description: "Encrypt file names, requires `encryption`",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"encryption_password": &schema.Schema{
+				Description: "Encryption password, required by `encryption`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"encryption_salt": &schema.Schema{
+				Description: "Encryption salt, recommended with `encryption`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"bwlimit": &schema.Schema{
+				Description: "Bandwidth limit schedule, each limit applies from its time of day until the next one",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": &schema.Schema{
+							Description:  "Time of day in `HH:MM` format",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(bwLimitTimeRegexp, "time must be in HH:MM format"),
+						},
+						"bandwidth": &schema.Schema{
+							Description:  "Bandwidth limit in bytes per second, `0` for unlimited",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"exclude": &schema.Schema{
+				Description: "Exclude patterns, eg. `*.tmp`, `.cache/**`",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"pre_script": &schema.Schema{
+				Description: "Script to run before the transfer",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"post_script": &schema.Schema{
+				Description: "Script to run after a successful transfer",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"schedule": cronScheduleSchema("Task schedule"),
+		},
+	}
+}
+
+func resourceTrueNASCloudSyncTaskRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var task CloudSyncTask
+
+	if err := apiGet(ctx, m, "/cloudsync/id/"+d.Id(), nil, &task); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS cloud sync task (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting cloud sync task: %s", err)
+	}
+
+	d.Set("description", task.Description)
+	d.Set("direction", task.Direction)
+	d.Set("transfer_mode", task.TransferMode)
+	d.Set("path", task.Path)
+	d.Set("enabled", task.Enabled)
+	d.Set("snapshot", task.Snapshot)
+	d.Set("follow_symlinks", task.FollowSymlinks)
+	d.Set("encryption", task.Encryption)
+	d.Set("filename_encryption", task.FilenameEncryption)
+	d.Set("exclude", flattenStringList(task.Exclude))
+	d.Set("pre_script", task.PreScript)
+	d.Set("post_script", task.PostScript)
+
+	if credentials, ok := task.Credentials.(map[string]interface{}); ok {
+		if id, ok := credentials["id"].(float64); ok {
+			d.Set("credentials", int(id))
+		}
+	}
+
+	bucket, _ := task.Attributes["bucket"].(string)
+	folder, _ := task.Attributes["folder"].(string)
+
+	d.Set("bucket", bucket)
+	d.Set("folder", folder)
+
+	if task.Transfers != nil {
+		d.Set("transfers", *task.Transfers)
+	} else {
+		d.Set("transfers", nil)
+	}
+
+	// secrets are not returned by some versions
+	if task.EncryptionPassword != "" {
+		d.Set("encryption_password", task.EncryptionPassword)
+	}
+
+	if task.EncryptionSalt != "" {
+		d.Set("encryption_salt", task.EncryptionSalt)
+	}
+
+	if err := d.Set("bwlimit", flattenCloudSyncBWLimit(task.BWLimit)); err != nil {
+		return diag.Errorf("error setting bwlimit: %s", err)
+	}
+
+	if task.Schedule != nil {
+		if err := d.Set("schedule", flattenSchedule(*task.Schedule)); err != nil {
+			return diag.Errorf("error setting schedule: %s", err)
+		}
+	}
+
+	return diags
+}
+
+func resourceTrueNASCloudSyncTaskCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var task CloudSyncTask
+
+	if err := apiPost(ctx, m, "/cloudsync", expandCloudSyncTask(d), &task); err != nil {
+		return diag.Errorf("error creating cloud sync task: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(task.Id))
+
+	return resourceTrueNASCloudSyncTaskRead(ctx, d, m)
+}
+
+func resourceTrueNASCloudSyncTaskUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := apiPut(ctx, m, "/cloudsync/id/"+d.Id(), expandCloudSyncTask(d), nil); err != nil {
+		return diag.Errorf("error updating cloud sync task: %s", err)
+	}
+
+	return resourceTrueNASCloudSyncTaskRead(ctx, d, m)
+}
+
+func resourceTrueNASCloudSyncTaskDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS cloud sync task: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/cloudsync/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting cloud sync task: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS cloud sync task (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTrueNASCloudSyncTaskCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	encryption := d.Get("encryption").(bool)
+
+	if d.Get("filename_encryption").(bool) && !encryption {
+		return fmt.Errorf("filename_encryption requires encryption to be enabled")
+	}
+
+	if encryption && d.NewValueKnown("encryption_password") && d.Get("encryption_password").(string) == "" {
+		return fmt.Errorf("encryption_password is required when encryption is enabled")
+	}
+
+	return nil
+}
+
+func expandCloudSyncTask(d *schema.ResourceData) CloudSyncTask {
+	task := CloudSyncTask{
+		Description:        d.Get("description").(string),
+		Direction:          d.Get("direction").(string),
+		TransferMode:       d.Get("transfer_mode").(string),
+		Path:               d.Get("path").(string),
+		Credentials:        d.Get("credentials").(int),
+		Attributes:         map[string]interface{}{},
+		Schedule:           expandJobSchedule(d.Get("schedule").([]interface{})),
+		Enabled:            d.Get("enabled").(bool),
+		Snapshot:           d.Get("snapshot").(bool),
+		FollowSymlinks:     d.Get("follow_symlinks").(bool),
+		Encryption:         d.Get("encryption").(bool),
+		FilenameEncryption: d.Get("filename_encryption").(bool),
+		EncryptionPassword: d.Get("encryption_password").(string),
+		EncryptionSalt:     d.Get("encryption_salt").(string),
+		BWLimit:            expandCloudSyncBWLimit(d.Get("bwlimit").([]interface{})),
+		Exclude:            expandStrings(d.Get("exclude").([]interface{})),
+		PreScript:          d.Get("pre_script").(string),
+		PostScript:         d.Get("post_script").(string),
+	}
+
+	if bucket := d.Get("bucket").(string); bucket != "" {
+		task.Attributes["bucket"] = bucket
+	}
+
+	task.Attributes["folder"] = d.Get("folder").(string)
+
+	if transfers, ok := d.GetOk("transfers"); ok {
+		t := transfers.(int)
+		task.Transfers = &t
+	}
+
+	return task
+}
+
+func expandCloudSyncBWLimit(l []interface{}) []CloudSyncBWLimit {
+	res := []CloudSyncBWLimit{}
+
+	for _, item := range l {
+		limit := item.(map[string]interface{})
+
+		bwlimit := CloudSyncBWLimit{
+			Time: limit["time"].(string),
+		}
+
+		if bandwidth := limit["bandwidth"].(int); bandwidth > 0 {
+			bwlimit.Bandwidth = &bandwidth
+		}
+
+		res = append(res, bwlimit)
+	}
+
+	return res
+}
+
+func flattenCloudSyncBWLimit(l []CloudSyncBWLimit) []interface{} {
+	res := make([]interface{}, 0, len(l))
+
+	for _, limit := range l {
+		bandwidth := 0
+
+		if limit.Bandwidth != nil {
+			bandwidth = *limit.Bandwidth
+		}
+
+		res = append(res, map[string]interface{}{
+			"time":      limit.Time,
+			"bandwidth": bandwidth,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,99 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAccResourceTruenasCloudSyncTask_basic(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	name := fmt.Sprintf("%s-%s", testResourcePrefix, suffix)
+	resourceName := "truenas_cloud_sync_task.task"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasCloudSyncTaskConfig(testPoolName, name, "COPY"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", name),
+					resource.TestCheckResourceAttr(resourceName, "direction", "PUSH"),
+					resource.TestCheckResourceAttr(resourceName, "transfer_mode", "COPY"),
+					resource.TestCheckResourceAttr(resourceName, "bucket", "truenas-backup"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.hour", "2"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasCloudSyncTaskConfig(testPoolName, name, "SYNC"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "transfer_mode", "SYNC"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasCloudSyncTaskConfig(pool string, name string, transferMode string) string {
+	return fmt.Sprintf(`
+		resource "truenas_dataset" "test" {
+			name = "%s"
+			pool = "%s"
+		}
+
+		resource "truenas_cloud_credential" "s3" {
+			name = "%s"
+
+			s3 {
+				access_key_id = "AKIAEXAMPLE"
+				secret_access_key = "secret"
+				endpoint = "https://s3.example.com"
+				region = "us-east-1"
+				skip_region = true
+			}
+		}
+
+		resource "truenas_cloud_sync_task" "task" {
+			description = "%s"
+			direction = "PUSH"
+			transfer_mode = "%s"
+			path = truenas_dataset.test.mount_point
+			credentials = truenas_cloud_credential.s3.id
+			bucket = "truenas-backup"
+			folder = "data"
+			enabled = false
+
+			schedule {
+				minute = "0"
+				hour = "2"
+			}
+		}
+	`, name, pool, name, name, transferMode)
+}
+
+func TestCloudSyncBWLimit(t *testing.T) {
+	bandwidth := 1048576
+
+	limits := []CloudSyncBWLimit{
+		{Time: "08:00", Bandwidth: &bandwidth},
+		{Time: "18:00"},
+	}
+
+	flattened := flattenCloudSyncBWLimit(limits)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"time": "08:00", "bandwidth": 1048576},
+		map[string]interface{}{"time": "18:00", "bandwidth": 0},
+	}, flattened)
+
+	assert.Equal(t, limits, expandCloudSyncBWLimit(flattened))
+}
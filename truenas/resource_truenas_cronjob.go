@@ -57,41 +57,7 @@ func resourceTrueNASCronjob() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
-			"schedule": &schema.Schema{
-				Description: "Cronjob schedule",
-				Type:        schema.TypeList,
-				Required:    true,
-				MaxItems:    1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"minute": &schema.Schema{
-							Type:     schema.TypeString,
-							Default:  "00",
-							Optional: true,
-						},
-						"hour": &schema.Schema{
-							Type:     schema.TypeString,
-							Default:  "*",
-							Optional: true,
-						},
-						"dom": &schema.Schema{
-							Type:     schema.TypeString,
-							Default:  "*",
-							Optional: true,
-						},
-						"month": &schema.Schema{
-							Type:     schema.TypeString,
-							Default:  "*",
-							Optional: true,
-						},
-						"dow": &schema.Schema{
-							Type:     schema.TypeString,
-							Default:  "*",
-							Optional: true,
-						},
-					},
-				},
-			},
+			"schedule": cronScheduleSchema("Cronjob schedule"),
 		},
 	}
 }
@@ -99,7 +65,7 @@ func resourceTrueNASCronjob() *schema.Resource {
 func resourceTrueNASCronjobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	id, err := strconv.Atoi(d.Id())
 
@@ -145,7 +111,7 @@ func resourceTrueNASCronjobRead(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceTrueNASCronjobCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	job := expandJobInput(d)
 
 	resp, _, err := c.CronjobApi.CreateCronJob(ctx).
@@ -166,7 +132,7 @@ func resourceTrueNASCronjobCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceTrueNASCronjobUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	job := expandJobInput(d)
 
 	id, err := strconv.Atoi(d.Id())
@@ -191,7 +157,7 @@ func resourceTrueNASCronjobUpdate(ctx context.Context, d *schema.ResourceData, m
 func resourceTrueNASCronjobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	id, err := strconv.Atoi(d.Id())
 
@@ -265,3 +231,42 @@ func expandJobSchedule(s []interface{}) *api.CronJobSchedule {
 
 	return schedule
 }
+
+// cronScheduleSchema returns schedule block shared by periodic tasks
+func cronScheduleSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"minute": &schema.Schema{
+					Type:     schema.TypeString,
+					Default:  "00",
+					Optional: true,
+				},
+				"hour": &schema.Schema{
+					Type:     schema.TypeString,
+					Default:  "*",
+					Optional: true,
+				},
+				"dom": &schema.Schema{
+					Type:     schema.TypeString,
+					Default:  "*",
+					Optional: true,
+				},
+				"month": &schema.Schema{
+					Type:     schema.TypeString,
+					Default:  "*",
+					Optional: true,
+				},
+				"dow": &schema.Schema{
+					Type:     schema.TypeString,
+					Default:  "*",
+					Optional: true,
+				},
+			},
+		},
+	}
+}
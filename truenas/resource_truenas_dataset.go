@@ -268,7 +268,7 @@ func resourceTrueNASDataset() *schema.Resource {
 }
 
 func resourceTrueNASDatasetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	input := expandDataset(d)
 
@@ -294,7 +294,7 @@ func resourceTrueNASDatasetCreate(ctx context.Context, d *schema.ResourceData, m
 func resourceTrueNASDatasetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	id := d.Id()
 
@@ -471,7 +471,7 @@ func resourceTrueNASDatasetRead(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceTrueNASDatasetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	input := expandDatasetForUpdate(d)
 
@@ -495,7 +495,7 @@ func resourceTrueNASDatasetUpdate(ctx context.Context, d *schema.ResourceData, m
 func resourceTrueNASDatasetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id := d.Id()
 
 	log.Printf("[DEBUG] Deleting TrueNAS dataset: %s", id)
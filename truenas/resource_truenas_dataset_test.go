@@ -51,7 +51,7 @@ func TestAccResourceTruenasDataset_basic(t *testing.T) {
 }
 
 func testAccCheckResourceTruenasDatasetDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*api.APIClient)
+	client := testAccProvider.Meta().(*providerMeta)
 
 	// loop through the resources in state, verifying each widget
 	// is destroyed
@@ -147,7 +147,7 @@ func testAccCheckTruenasDatasetResourceExists(n string, dataset *api.Dataset) re
 			return fmt.Errorf("no dataset ID is set")
 		}
 
-		client := testAccProvider.Meta().(*api.APIClient)
+		client := testAccProvider.Meta().(*providerMeta)
 
 		resp, _, err := client.DatasetApi.GetDataset(context.Background(), rs.Primary.ID).Execute()
 
@@ -77,7 +77,7 @@ func resourceTrueNASGroup() *schema.Resource {
 func resourceTrueNASGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -124,7 +124,7 @@ func resourceTrueNASGroupRead(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceTrueNASGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	input := expandGroup(d)
 
@@ -138,6 +138,8 @@ func resourceTrueNASGroupCreate(ctx context.Context, d *schema.ResourceData, m i
 		return diag.Errorf("error creating group: %s\n%s", err, body)
 	}
 
+	getIdentityCache(m).Invalidate()
+
 	d.SetId(strconv.Itoa(int(resp)))
 
 	return resourceTrueNASGroupRead(ctx, d, m)
@@ -146,7 +148,7 @@ func resourceTrueNASGroupCreate(ctx context.Context, d *schema.ResourceData, m i
 func resourceTrueNASGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -167,6 +169,8 @@ func resourceTrueNASGroupDelete(ctx context.Context, d *schema.ResourceData, m i
 		return diag.Errorf("error deleting group: %s\n%s", err, body)
 	}
 
+	getIdentityCache(m).Invalidate()
+
 	log.Printf("[INFO] TrueNAS group (%s) deleted", d.Id())
 	d.SetId("")
 
@@ -174,7 +178,7 @@ func resourceTrueNASGroupDelete(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceTrueNASGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -194,6 +198,8 @@ func resourceTrueNASGroupUpdate(ctx context.Context, d *schema.ResourceData, m i
 		return diag.Errorf("error updating group: %s\n%s", err, body)
 	}
 
+	getIdentityCache(m).Invalidate()
+
 	return resourceTrueNASGroupRead(ctx, d, m)
 }
 
@@ -0,0 +1,132 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"strconv"
+)
+
+func resourceTrueNASGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages complete list of group members, users not listed are removed from the group. Do not combine with `truenas_group_membership` for the same group, or with `truenas_user.group_ids` for the same users.",
+		CreateContext: resourceTrueNASGroupMembersCreate,
+		ReadContext:   resourceTrueNASGroupMembersRead,
+		UpdateContext: resourceTrueNASGroupMembersUpdate,
+		DeleteContext: resourceTrueNASGroupMembersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTrueNASGroupMembersImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": &schema.Schema{
+				Description: "Group ID (TrueNAS internal ID, same as `truenas_group` resource ID)",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"user_ids": &schema.Schema{
+				Description: "IDs of all group members (TrueNAS internal IDs, same as `truenas_user` resource ID)",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func resourceTrueNASGroupMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*providerMeta)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, http, err := c.GroupApi.GetGroup(ctx, int32(id)).Execute()
+
+	if err != nil {
+		// gracefully handle manual deletions
+		if http != nil && http.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting group: %s", err)
+	}
+
+	d.Set("group_id", int(resp.Id))
+	d.Set("user_ids", flattenInt32List(resp.Users))
+
+	return diags
+}
+
+func resourceTrueNASGroupMembersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupID := d.Get("group_id").(int)
+
+	if diags := resourceTrueNASGroupMembersSet(ctx, d, m, groupID); diags != nil {
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(groupID))
+
+	return resourceTrueNASGroupMembersRead(ctx, d, m)
+}
+
+func resourceTrueNASGroupMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if diags := resourceTrueNASGroupMembersSet(ctx, d, m, d.Get("group_id").(int)); diags != nil {
+		return diags
+	}
+
+	return resourceTrueNASGroupMembersRead(ctx, d, m)
+}
+
+func resourceTrueNASGroupMembersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	groupID := d.Get("group_id").(int)
+
+	log.Printf("[DEBUG] Removing all members from TrueNAS group: %d", groupID)
+
+	if err := updateGroupUsers(ctx, m, groupID, nil, expandGroupUserIDs(d.Get("user_ids").(*schema.Set).List())); err != nil {
+		return diag.Errorf("error deleting group members: %s", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTrueNASGroupMembersImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	groupID, err := strconv.Atoi(d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("group_id", groupID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceTrueNASGroupMembersSet(ctx context.Context, d *schema.ResourceData, m interface{}, groupID int) diag.Diagnostics {
+	key := strconv.Itoa(groupID)
+	groupMembershipMutex.Lock(key)
+	defer groupMembershipMutex.Unlock(key)
+
+	c := m.(*providerMeta)
+
+	group, _, err := c.GroupApi.GetGroup(ctx, int32(groupID)).Execute()
+
+	if err != nil {
+		return diag.Errorf("error getting group %d: %s", groupID, err)
+	}
+
+	if err := setGroupUsers(ctx, m, *group, expandGroupUserIDs(d.Get("user_ids").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
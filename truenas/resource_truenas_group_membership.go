@@ -0,0 +1,235 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var groupMembershipMutex = newMutexKV()
+
+func resourceTrueNASGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Adds users to a group without affecting other group members. Do not combine with `truenas_group_members` for the same group, or with `truenas_user.group_ids` for the same users.",
+		CreateContext: resourceTrueNASGroupMembershipCreate,
+		ReadContext:   resourceTrueNASGroupMembershipRead,
+		UpdateContext: resourceTrueNASGroupMembershipUpdate,
+		DeleteContext: resourceTrueNASGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTrueNASGroupMembershipImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": &schema.Schema{
+				Description: "Group ID (TrueNAS internal ID, same as `truenas_group` resource ID)",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"user_ids": &schema.Schema{
+				Description: "IDs of users to add to the group (TrueNAS internal IDs, same as `truenas_user` resource ID)",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func resourceTrueNASGroupMembershipRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*providerMeta)
+	id := d.Get("group_id").(int)
+
+	resp, http, err := c.GroupApi.GetGroup(ctx, int32(id)).Execute()
+
+	if err != nil {
+		// gracefully handle manual deletions
+		if http != nil && http.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting group: %s", err)
+	}
+
+	members := make(map[int]bool, len(resp.Users))
+
+	for _, u := range resp.Users {
+		members[int(u)] = true
+	}
+
+	// only track users managed by this resource
+	var userIDs []int
+
+	for _, u := range d.Get("user_ids").(*schema.Set).List() {
+		if members[u.(int)] {
+			userIDs = append(userIDs, u.(int))
+		}
+	}
+
+	d.Set("user_ids", userIDs)
+
+	return diags
+}
+
+func resourceTrueNASGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupID := d.Get("group_id").(int)
+	add := expandGroupUserIDs(d.Get("user_ids").(*schema.Set).List())
+
+	if err := updateGroupUsers(ctx, m, groupID, add, nil); err != nil {
+		return diag.Errorf("error creating group membership: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(groupID))
+
+	return resourceTrueNASGroupMembershipRead(ctx, d, m)
+}
+
+func resourceTrueNASGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupID := d.Get("group_id").(int)
+
+	o, n := d.GetChange("user_ids")
+	add := expandGroupUserIDs(n.(*schema.Set).Difference(o.(*schema.Set)).List())
+	remove := expandGroupUserIDs(o.(*schema.Set).Difference(n.(*schema.Set)).List())
+
+	if err := updateGroupUsers(ctx, m, groupID, add, remove); err != nil {
+		return diag.Errorf("error updating group membership: %s", err)
+	}
+
+	return resourceTrueNASGroupMembershipRead(ctx, d, m)
+}
+
+func resourceTrueNASGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	groupID := d.Get("group_id").(int)
+	remove := expandGroupUserIDs(d.Get("user_ids").(*schema.Set).List())
+
+	log.Printf("[DEBUG] Removing users %v from TrueNAS group: %d", remove, groupID)
+
+	if err := updateGroupUsers(ctx, m, groupID, nil, remove); err != nil {
+		return diag.Errorf("error deleting group membership: %s", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceTrueNASGroupMembershipImport expects ID in format <group_id>/<user_id>[,<user_id>...]
+func resourceTrueNASGroupMembershipImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <group_id>/<user_id>[,<user_id>...]", d.Id())
+	}
+
+	groupID, err := strconv.Atoi(parts[0])
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid group ID %q: %s", parts[0], err)
+	}
+
+	var userIDs []int
+
+	for _, s := range strings.Split(parts[1], ",") {
+		userID, err := strconv.Atoi(strings.TrimSpace(s))
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID %q: %s", s, err)
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	d.SetId(strconv.Itoa(groupID))
+	d.Set("group_id", groupID)
+	d.Set("user_ids", userIDs)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandGroupUserIDs(items []interface{}) []int32 {
+	result := make([]int32, 0, len(items))
+
+	for _, item := range items {
+		result = append(result, int32(item.(int)))
+	}
+
+	return result
+}
+
+// updateGroupUsers adds and removes group members, keeping other members intact
+func updateGroupUsers(ctx context.Context, m interface{}, groupID int, add []int32, remove []int32) error {
+	key := strconv.Itoa(groupID)
+	groupMembershipMutex.Lock(key)
+	defer groupMembershipMutex.Unlock(key)
+
+	c := m.(*providerMeta)
+
+	group, _, err := c.GroupApi.GetGroup(ctx, int32(groupID)).Execute()
+
+	if err != nil {
+		return fmt.Errorf("error getting group %d: %s", groupID, err)
+	}
+
+	members := make(map[int32]bool, len(group.Users)+len(add))
+
+	for _, u := range group.Users {
+		members[u] = true
+	}
+
+	for _, u := range add {
+		members[u] = true
+	}
+
+	for _, u := range remove {
+		delete(members, u)
+	}
+
+	users := make([]int32, 0, len(members))
+
+	for u := range members {
+		users = append(users, u)
+	}
+
+	return setGroupUsers(ctx, m, *group, users)
+}
+
+// setGroupUsers replaces all group members
+func setGroupUsers(ctx context.Context, m interface{}, group api.Group, users []int32) error {
+	c := m.(*providerMeta)
+
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+
+	input := api.CreateGroupParams{
+		Name: group.Group,
+		// users are omitted by SDK when empty, which would not remove last member
+		AdditionalProperties: map[string]interface{}{
+			"users": users,
+		},
+	}
+
+	_, _, err := c.GroupApi.UpdateGroup(ctx, group.Id).CreateGroupParams(input).Execute()
+
+	if err != nil {
+		var body []byte
+		if apiErr, ok := err.(*api.GenericOpenAPIError); ok {
+			body = apiErr.Body()
+		}
+		return fmt.Errorf("error updating group %d: %s\n%s", group.Id, err, body)
+	}
+
+	getIdentityCache(m).Invalidate()
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccResourceTruenasGroupMembership_basic(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	name := fmt.Sprintf("%s-%s", testResourcePrefix, suffix)
+	resourceName := "truenas_group_membership.membership"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasGroupMembershipConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "group_id", "truenas_group.group", "id"),
+					resource.TestCheckResourceAttr(resourceName, "user_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.truenas_group.group", "users.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasGroupMembershipConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "truenas_group" "group" {
+			name = "%[1]s"
+		}
+
+		resource "truenas_user" "user" {
+			name = "%[1]s"
+			full_name = "Test User"
+			create_group = true
+			password_disabled = true
+		}
+
+		resource "truenas_group_membership" "membership" {
+			group_id = truenas_group.group.id
+			user_ids = [truenas_user.user.id]
+		}
+
+		data "truenas_group" "group" {
+			group_id = truenas_group_membership.membership.group_id
+		}
+	`, name)
+}
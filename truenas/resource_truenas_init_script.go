@@ -0,0 +1,158 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strconv"
+)
+
+// InitScript is initshutdownscript middleware object
+type InitScript struct {
+	Id      int    `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Command string `json:"command"`
+	Script  string `json:"script"`
+	When    string `json:"when"`
+	Enabled bool   `json:"enabled"`
+	Timeout int    `json:"timeout"`
+	Comment string `json:"comment"`
+}
+
+func resourceTrueNASInitScript() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates an init or shutdown script, which runs a command or script file on system boot or shutdown",
+		CreateContext: resourceTrueNASInitScriptCreate,
+		ReadContext:   resourceTrueNASInitScriptRead,
+		UpdateContext: resourceTrueNASInitScriptUpdate,
+		DeleteContext: resourceTrueNASInitScriptDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"command": &schema.Schema{
+				Description:  "Command to run",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"command", "script"},
+			},
+			"script": &schema.Schema{
+				Description:  "Path of script file to run, eg. `/mnt/tank/scripts/init.sh`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"command", "script"},
+			},
+			"when": &schema.Schema{
+				Description:  "When to run: `PREINIT` (early boot, before services start), `POSTINIT` (after boot) or `SHUTDOWN`",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PREINIT", "POSTINIT", "SHUTDOWN"}, false),
+			},
+			"enabled": &schema.Schema{
+				Description: "`true` if script runs",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"timeout": &schema.Schema{
+				Description:  "Seconds to wait for the command or script to finish",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"comment": &schema.Schema{
+				Description: "Script description",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func resourceTrueNASInitScriptRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var script InitScript
+
+	if err := apiGet(ctx, m, "/initshutdownscript/id/"+d.Id(), nil, &script); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS init script (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting init script: %s", err)
+	}
+
+	// both are returned, only the one matching type is used
+	if script.Type == "SCRIPT" {
+		d.Set("command", "")
+		d.Set("script", script.Script)
+	} else {
+		d.Set("command", script.Command)
+		d.Set("script", "")
+	}
+
+	d.Set("when", script.When)
+	d.Set("enabled", script.Enabled)
+	d.Set("timeout", script.Timeout)
+	d.Set("comment", script.Comment)
+
+	return diags
+}
+
+func resourceTrueNASInitScriptCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var script InitScript
+
+	if err := apiPost(ctx, m, "/initshutdownscript", expandInitScript(d), &script); err != nil {
+		return diag.Errorf("error creating init script: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(script.Id))
+
+	return resourceTrueNASInitScriptRead(ctx, d, m)
+}
+
+func resourceTrueNASInitScriptUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := apiPut(ctx, m, "/initshutdownscript/id/"+d.Id(), expandInitScript(d), nil); err != nil {
+		return diag.Errorf("error updating init script: %s", err)
+	}
+
+	return resourceTrueNASInitScriptRead(ctx, d, m)
+}
+
+func resourceTrueNASInitScriptDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS init script: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/initshutdownscript/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting init script: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS init script (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func expandInitScript(d *schema.ResourceData) InitScript {
+	script := InitScript{
+		Type:    "COMMAND",
+		Command: d.Get("command").(string),
+		Script:  d.Get("script").(string),
+		When:    d.Get("when").(string),
+		Enabled: d.Get("enabled").(bool),
+		Timeout: d.Get("timeout").(int),
+		Comment: d.Get("comment").(string),
+	}
+
+	if script.Script != "" {
+		script.Type = "SCRIPT"
+	}
+
+	return script
+}
@@ -0,0 +1,49 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccResourceTruenasInitScript_basic(t *testing.T) {
+	resourceName := "truenas_init_script.script"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasInitScriptConfig("POSTINIT"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "command", "logger terraform"),
+					resource.TestCheckResourceAttr(resourceName, "script", ""),
+					resource.TestCheckResourceAttr(resourceName, "when", "POSTINIT"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasInitScriptConfig("SHUTDOWN"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "when", "SHUTDOWN"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasInitScriptConfig(when string) string {
+	return fmt.Sprintf(`
+		resource "truenas_init_script" "script" {
+			command = "logger terraform"
+			when = "%s"
+			enabled = false
+			comment = "%s"
+		}
+	`, when, testResourcePrefix)
+}
@@ -0,0 +1,258 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+const mailConfigID = "mail-config"
+
+// attributes with the same name in API
+var mailConfigAttributes = []string{
+	"fromemail",
+	"fromname",
+	"outgoingserver",
+	"port",
+	"security",
+	"smtp",
+	"user",
+}
+
+func resourceTrueNASMailConfig() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages outgoing mail configuration used by alerts and reports. There is only one mail configuration per system, only changed attributes are sent and attributes not set are left unchanged. Deleting the resource leaves configuration as is.",
+		CreateContext: resourceTrueNASMailConfigCreate,
+		ReadContext:   resourceTrueNASMailConfigRead,
+		UpdateContext: resourceTrueNASMailConfigUpdate,
+		DeleteContext: resourceTrueNASMailConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"fromemail": &schema.Schema{
+				Description: "Sender email address",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"fromname": &schema.Schema{
+				Description: "Sender name",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"outgoingserver": &schema.Schema{
+				Description: "SMTP server hostname or IP address",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"port": &schema.Schema{
+				Description:  "SMTP server port",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"security": &schema.Schema{
+				Description:  "Connection security: `PLAIN`, `SSL` (implicit TLS) or `TLS` (STARTTLS)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PLAIN", "SSL", "TLS"}, false),
+			},
+			"smtp": &schema.Schema{
+				Description: "Use SMTP authentication with `user` and `pass`",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"user": &schema.Schema{
+				Description: "SMTP username",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"pass": &schema.Schema{
+				Description: "SMTP password. Cannot be read, changes made outside of Terraform are not detected.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"oauth": &schema.Schema{
+				Description: "OAuth authentication for Gmail or Outlook, replaces SMTP settings. Tokens are obtained with TrueNAS web interface.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"provider": &schema.Schema{
+							Description:  "OAuth provider: `gmail` or `outlook` (TrueNAS SCALE 24.04 or newer)",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"gmail", "outlook"}, false),
+						},
+						"client_id": &schema.Schema{
+							Description: "OAuth client ID",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"client_secret": &schema.Schema{
+							Description: "OAuth client secret",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"refresh_token": &schema.Schema{
+							Description: "OAuth refresh token",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"send_test_email_to": &schema.Schema{
+				Description: "Send a test email to this address after configuration changes, fails if it cannot be sent",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func resourceTrueNASMailConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	config, err := getMailConfig(ctx, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := flattenConfigAttributes(d, config, mailConfigAttributes); err != nil {
+		return diag.Errorf("error reading mail configuration: %s", err)
+	}
+
+	oauth, _ := config["oauth"].(map[string]interface{})
+
+	if clientID, _ := oauth["client_id"].(string); clientID != "" {
+		oauthSchema := resourceTrueNASMailConfig().Schema["oauth"].Elem.(*schema.Resource).Schema
+
+		if err := d.Set("oauth", flattenTypedAttributes(d, "oauth", oauthSchema, oauth)); err != nil {
+			return diag.Errorf("error setting oauth: %s", err)
+		}
+	} else {
+		d.Set("oauth", nil)
+	}
+
+	return diags
+}
+
+func resourceTrueNASMailConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateMailConfig(ctx, m, d); err != nil {
+		return diag.Errorf("error creating mail configuration: %s", err)
+	}
+
+	d.SetId(mailConfigID)
+
+	if err := sendTestMail(ctx, m, d.Get("send_test_email_to").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTrueNASMailConfigRead(ctx, d, m)
+}
+
+func resourceTrueNASMailConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateMailConfig(ctx, m, d); err != nil {
+		return diag.Errorf("error updating mail configuration: %s", err)
+	}
+
+	if err := sendTestMail(ctx, m, d.Get("send_test_email_to").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTrueNASMailConfigRead(ctx, d, m)
+}
+
+func resourceTrueNASMailConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// alerts depend on mail configuration
+	log.Printf("[DEBUG] Removing TrueNAS mail configuration from state, configuration is left as is")
+	d.SetId("")
+
+	return diags
+}
+
+func getMailConfig(ctx context.Context, m interface{}) (map[string]interface{}, error) {
+	var config map[string]interface{}
+
+	if err := apiGet(ctx, m, "/mail", nil, &config); err != nil {
+		return nil, fmt.Errorf("error getting mail configuration: %s", err)
+	}
+
+	return config, nil
+}
+
+func updateMailConfig(ctx context.Context, m interface{}, d *schema.ResourceData) error {
+	input := expandConfigAttributes(d, mailConfigAttributes)
+
+	changed := func(attr string) bool {
+		if d.IsNewResource() {
+			return isConfigured(d, attr)
+		}
+		return d.HasChange(attr)
+	}
+
+	if changed("pass") {
+		input["pass"] = d.Get("pass").(string)
+	}
+
+	if changed("oauth") {
+		oauth := map[string]interface{}{}
+
+		if l, ok := d.Get("oauth").([]interface{}); ok && len(l) > 0 && l[0] != nil {
+			for k, v := range l[0].(map[string]interface{}) {
+				if v != "" {
+					oauth[k] = v
+				}
+			}
+		}
+
+		input["oauth"] = oauth
+	}
+
+	if len(input) == 0 {
+		return nil
+	}
+
+	return apiPut(ctx, m, "/mail", input, nil)
+}
+
+// sendTestMail sends test email using saved configuration, does nothing if to is empty
+func sendTestMail(ctx context.Context, m interface{}, to string) error {
+	if to == "" {
+		return nil
+	}
+
+	input := map[string]interface{}{
+		"mail_message": map[string]interface{}{
+			"subject": "TrueNAS test email",
+			"text":    "This is a test email sent by Terraform after mail configuration change.",
+			"to":      []string{to},
+		},
+	}
+
+	log.Printf("[DEBUG] Sending TrueNAS test email to %s", to)
+
+	if err := apiPostJob(ctx, m, "/mail/send", input, nil); err != nil {
+		return fmt.Errorf("error sending test email to %s: %s", to, err)
+	}
+
+	return nil
+}
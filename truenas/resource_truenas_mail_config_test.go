@@ -0,0 +1,41 @@
+package truenas
+
+import (
+	"context"
+	"encoding/json"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendTestMail(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var input map[string]map[string]interface{}
+
+		assert.Equal(t, "/mail/send", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		assert.Equal(t, []interface{}{"admin@example.com"}, input["mail_message"]["to"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`true`))
+	}))
+	defer server.Close()
+
+	config := api.NewConfiguration()
+	config.Servers = api.ServerConfigurations{{URL: server.URL}}
+	client := newProviderMeta(api.NewAPIClient(config))
+
+	ctx := context.Background()
+
+	assert.NoError(t, sendTestMail(ctx, client, ""))
+	assert.Equal(t, 0, requests)
+
+	assert.NoError(t, sendTestMail(ctx, client, "admin@example.com"))
+	assert.Equal(t, 1, requests)
+}
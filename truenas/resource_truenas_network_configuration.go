@@ -0,0 +1,284 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strings"
+)
+
+const networkConfigurationID = "network-configuration"
+
+// attributes with the same name in API
+var networkConfigurationAttributes = []string{
+	"hostname",
+	"domain",
+	"ipv4gateway",
+	"ipv6gateway",
+	"nameserver1",
+	"nameserver2",
+	"nameserver3",
+	"httpproxy",
+	"netwait_enabled",
+	"domains",
+}
+
+func resourceTrueNASNetworkConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages global network configuration. There is only one network configuration per system, only changed attributes are sent and attributes not set are left unchanged. Deleting the resource leaves configuration as is.",
+		CreateContext: resourceTrueNASNetworkConfigurationCreate,
+		ReadContext:   resourceTrueNASNetworkConfigurationRead,
+		UpdateContext: resourceTrueNASNetworkConfigurationUpdate,
+		DeleteContext: resourceTrueNASNetworkConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"hostname": &schema.Schema{
+				Description: "TrueNAS hostname",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"domain": &schema.Schema{
+				Description: "TrueNAS domain",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"domains": &schema.Schema{
+				Description: "Additional search domains",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ipv4gateway": &schema.Schema{
+				Description:  "Gateway IPv4 address",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.Any(validation.IsIPv4Address, validation.StringIsEmpty),
+			},
+			"ipv6gateway": &schema.Schema{
+				Description:  "Gateway IPv6 address",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.Any(validation.IsIPv6Address, validation.StringIsEmpty),
+			},
+			"nameserver1": &schema.Schema{
+				Description:  "Nameserver 1 IP address",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.Any(validation.IsIPAddress, validation.StringIsEmpty),
+			},
+			"nameserver2": &schema.Schema{
+				Description:  "Nameserver 2 IP address",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.Any(validation.IsIPAddress, validation.StringIsEmpty),
+			},
+			"nameserver3": &schema.Schema{
+				Description:  "Nameserver 3 IP address",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.Any(validation.IsIPAddress, validation.StringIsEmpty),
+			},
+			"httpproxy": &schema.Schema{
+				Description: "HTTP proxy address",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"netwait_enabled": &schema.Schema{
+				Description: "Delay service startup until `netwait_ips` respond to ping",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"netwait_ips": &schema.Schema{
+				Description: "List of IP addresses to ping if netwait is enabled",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPAddress,
+				},
+			},
+			"hosts": &schema.Schema{
+				Description: "Additional /etc/hosts entries, eg. `192.168.1.20 backup.example.com backup`",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"service_announcement": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"netbios": &schema.Schema{
+							Description: "Advertises the SMB service NetBIOS Name",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"mdns": &schema.Schema{
+							Description: "Multicast DNS. Uses the system Hostname to advertise enabled and running services",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"wsd": &schema.Schema{
+							Description: "Uses the SMB Service NetBIOS Name to advertise the server to WS-Discovery clients",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTrueNASNetworkConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	config, err := getNetworkConfiguration(ctx, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := flattenConfigAttributes(d, config, networkConfigurationAttributes); err != nil {
+		return diag.Errorf("error reading network configuration: %s", err)
+	}
+
+	if netwaitIPs, ok := config["netwait_ip"]; ok {
+		d.Set("netwait_ips", netwaitIPs)
+	}
+
+	d.Set("hosts", flattenNetworkHosts(config["hosts"]))
+
+	if announcement, ok := config["service_announcement"].(map[string]interface{}); ok {
+		if err := d.Set("service_announcement", []interface{}{announcement}); err != nil {
+			return diag.Errorf("error setting service_announcement: %s", err)
+		}
+	}
+
+	return diags
+}
+
+func resourceTrueNASNetworkConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateNetworkConfiguration(ctx, m, d); err != nil {
+		return diag.Errorf("error creating network configuration: %s", err)
+	}
+
+	d.SetId(networkConfigurationID)
+
+	return resourceTrueNASNetworkConfigurationRead(ctx, d, m)
+}
+
+func resourceTrueNASNetworkConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateNetworkConfiguration(ctx, m, d); err != nil {
+		return diag.Errorf("error updating network configuration: %s", err)
+	}
+
+	return resourceTrueNASNetworkConfigurationRead(ctx, d, m)
+}
+
+func resourceTrueNASNetworkConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// resetting hostname, gateways or nameservers could make TrueNAS unreachable
+	log.Printf("[DEBUG] Removing TrueNAS network configuration from state, configuration is left as is")
+	d.SetId("")
+
+	return diags
+}
+
+func getNetworkConfiguration(ctx context.Context, m interface{}) (map[string]interface{}, error) {
+	var config map[string]interface{}
+
+	if err := apiGet(ctx, m, "/network/configuration", nil, &config); err != nil {
+		return nil, fmt.Errorf("error getting network configuration: %s", err)
+	}
+
+	return config, nil
+}
+
+func updateNetworkConfiguration(ctx context.Context, m interface{}, d *schema.ResourceData) error {
+	input := expandConfigAttributes(d, networkConfigurationAttributes)
+
+	changed := func(attr string) bool {
+		if d.IsNewResource() {
+			return isConfigured(d, attr)
+		}
+		return d.HasChange(attr)
+	}
+
+	if changed("netwait_ips") {
+		input["netwait_ip"] = expandStrings(d.Get("netwait_ips").([]interface{}))
+	}
+
+	if changed("service_announcement") {
+		if announcement, ok := d.Get("service_announcement").([]interface{}); ok && len(announcement) > 0 && announcement[0] != nil {
+			input["service_announcement"] = announcement[0]
+		}
+	}
+
+	if changed("hosts") {
+		// hosts is a string on TrueNAS CORE, list on SCALE
+		config, err := getNetworkConfiguration(ctx, m)
+
+		if err != nil {
+			return err
+		}
+
+		hosts := expandStrings(d.Get("hosts").([]interface{}))
+
+		if _, ok := config["hosts"].(string); ok {
+			input["hosts"] = strings.Join(hosts, "\n")
+		} else {
+			input["hosts"] = hosts
+		}
+	}
+
+	if len(input) == 0 {
+		return nil
+	}
+
+	return apiPut(ctx, m, "/network/configuration", input, nil)
+}
+
+func flattenNetworkHosts(hosts interface{}) []interface{} {
+	res := []interface{}{}
+
+	switch v := hosts.(type) {
+	case string:
+		for _, line := range strings.Split(v, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				res = append(res, line)
+			}
+		}
+	case []interface{}:
+		res = v
+	}
+
+	return res
+}
@@ -0,0 +1,12 @@
+package truenas
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFlattenNetworkHosts(t *testing.T) {
+	assert.Equal(t, []interface{}{"10.0.0.1 a", "10.0.0.2 b"}, flattenNetworkHosts("10.0.0.1 a\n\n 10.0.0.2 b \n"))
+	assert.Equal(t, []interface{}{"10.0.0.1 a"}, flattenNetworkHosts([]interface{}{"10.0.0.1 a"}))
+	assert.Equal(t, []interface{}{}, flattenNetworkHosts(nil))
+}
@@ -0,0 +1,542 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// NetworkInterface is interface middleware object
+type NetworkInterface struct {
+	Id                  string                  `json:"id"`
+	Name                string                  `json:"name"`
+	Type                string                  `json:"type"`
+	Description         string                  `json:"description"`
+	Ipv4Dhcp            bool                    `json:"ipv4_dhcp"`
+	Ipv6Auto            bool                    `json:"ipv6_auto"`
+	Aliases             []NetworkInterfaceAlias `json:"aliases"`
+	Mtu                 *int                    `json:"mtu"`
+	BridgeMembers       []string                `json:"bridge_members"`
+	Stp                 *bool                   `json:"stp"`
+	VlanParentInterface *string                 `json:"vlan_parent_interface"`
+	VlanTag             *int                    `json:"vlan_tag"`
+	VlanPcp             *int                    `json:"vlan_pcp"`
+	LagProtocol         *string                 `json:"lag_protocol"`
+	LagPorts            []string                `json:"lag_ports"`
+}
+
+type NetworkInterfaceAlias struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+	Netmask int    `json:"netmask"`
+}
+
+// interface changes are staged globally on the server, only one resource may stage and commit at a time
+var networkInterfaceMutex sync.Mutex
+
+// attributes allowed per interface type, others must not be set
+var networkInterfaceTypeAttributes = map[string][]string{
+	"BRIDGE":           {"bridge_members", "stp"},
+	"VLAN":             {"vlan_parent_interface", "vlan_tag", "vlan_pcp"},
+	"LINK_AGGREGATION": {"lag_protocol", "lag_ports"},
+}
+
+func resourceTrueNASNetworkInterface() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages network interface. Changes are committed with automatic rollback: if TrueNAS API is not reachable with new configuration, TrueNAS reverts it after `checkin_timeout`. PHYSICAL interfaces are not created, their configuration is managed and reset on delete.",
+		CreateContext: resourceTrueNASNetworkInterfaceCreate,
+		ReadContext:   resourceTrueNASNetworkInterfaceRead,
+		UpdateContext: resourceTrueNASNetworkInterfaceUpdate,
+		DeleteContext: resourceTrueNASNetworkInterfaceDelete,
+		CustomizeDiff: resourceTrueNASNetworkInterfaceCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Description: "Interface name, eg. `eno1`, `br0`, `vlan10`, `bond0`. Required for PHYSICAL interfaces, generated if not set for other types.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"type": &schema.Schema{
+				Description:  "Interface type: `PHYSICAL`, `BRIDGE`, `VLAN` or `LINK_AGGREGATION`",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PHYSICAL", "BRIDGE", "VLAN", "LINK_AGGREGATION"}, false),
+			},
+			"description": &schema.Schema{
+				Description: "Interface description",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ipv4_dhcp": &schema.Schema{
+				Description: "Configure IPv4 with DHCP",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"ipv6_auto": &schema.Schema{
+				Description: "Configure IPv6 with autoconfiguration",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"alias": &schema.Schema{
+				Description: "Static IP addresses",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": &schema.Schema{
+							Description:  "IPv4 or IPv6 address",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"netmask": &schema.Schema{
+							Description:  "Prefix length, eg. `24`",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 128),
+						},
+					},
+				},
+			},
+			"mtu": &schema.Schema{
+				Description:  "MTU, default is used if not set",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(68, 9216),
+			},
+			"bridge_members": &schema.Schema{
+				Description: "BRIDGE member interfaces",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"stp": &schema.Schema{
+				Description: "BRIDGE spanning tree protocol",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"vlan_parent_interface": &schema.Schema{
+				Description: "VLAN parent interface",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"vlan_tag": &schema.Schema{
+				Description:  "VLAN tag",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 4094),
+			},
+			"vlan_pcp": &schema.Schema{
+				Description:  "VLAN priority code point",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 7),
+			},
+			"lag_protocol": &schema.Schema{
+				Description:  "LINK_AGGREGATION protocol: `LACP`, `FAILOVER`, `LOADBALANCE`, `ROUNDROBIN` or `NONE`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"LACP", "FAILOVER", "LOADBALANCE", "ROUNDROBIN", "NONE"}, false),
+			},
+			"lag_ports": &schema.Schema{
+				Description: "LINK_AGGREGATION member interfaces, order matters for FAILOVER",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"checkin_timeout": &schema.Schema{
+				Description:  "Seconds TrueNAS waits for changes to be confirmed before rolling them back",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntBetween(10, 3600),
+			},
+		},
+	}
+}
+
+func resourceTrueNASNetworkInterfaceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	interfaceType := d.Get("type").(string)
+	config := d.GetRawConfig()
+
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	for t, attrs := range networkInterfaceTypeAttributes {
+		if t == interfaceType {
+			continue
+		}
+
+		for _, attr := range attrs {
+			if !config.GetAttr(attr).IsNull() {
+				return fmt.Errorf("%s can only be set for %s interfaces", attr, t)
+			}
+		}
+	}
+
+	// values may be unknown until apply, only check presence in configuration
+	var required []string
+
+	switch interfaceType {
+	case "PHYSICAL":
+		required = []string{"name"}
+	case "VLAN":
+		required = []string{"vlan_parent_interface", "vlan_tag"}
+	case "LINK_AGGREGATION":
+		required = []string{"lag_protocol"}
+	}
+
+	for _, attr := range required {
+		if config.GetAttr(attr).IsNull() {
+			return fmt.Errorf("%s is required for %s interfaces", attr, interfaceType)
+		}
+	}
+
+	return nil
+}
+
+func resourceTrueNASNetworkInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var iface NetworkInterface
+
+	if err := apiGet(ctx, m, networkInterfacePath(d.Id()), nil, &iface); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS network interface (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting network interface: %s", err)
+	}
+
+	d.Set("name", iface.Name)
+	d.Set("type", iface.Type)
+	d.Set("description", iface.Description)
+	d.Set("ipv4_dhcp", iface.Ipv4Dhcp)
+	d.Set("ipv6_auto", iface.Ipv6Auto)
+
+	if err := d.Set("alias", flattenNetworkInterfaceAliases(iface.Aliases)); err != nil {
+		return diag.Errorf("error setting alias: %s", err)
+	}
+
+	if iface.Mtu != nil {
+		d.Set("mtu", *iface.Mtu)
+	}
+
+	switch iface.Type {
+	case "BRIDGE":
+		d.Set("bridge_members", iface.BridgeMembers)
+
+		if iface.Stp != nil {
+			d.Set("stp", *iface.Stp)
+		}
+	case "VLAN":
+		if iface.VlanParentInterface != nil {
+			d.Set("vlan_parent_interface", *iface.VlanParentInterface)
+		}
+
+		if iface.VlanTag != nil {
+			d.Set("vlan_tag", *iface.VlanTag)
+		}
+
+		if iface.VlanPcp != nil {
+			d.Set("vlan_pcp", *iface.VlanPcp)
+		}
+	case "LINK_AGGREGATION":
+		if iface.LagProtocol != nil {
+			d.Set("lag_protocol", *iface.LagProtocol)
+		}
+
+		d.Set("lag_ports", iface.LagPorts)
+	}
+
+	return diags
+}
+
+func resourceTrueNASNetworkInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	networkInterfaceMutex.Lock()
+	defer networkInterfaceMutex.Unlock()
+
+	if err := checkNoPendingNetworkChanges(ctx, m); err != nil {
+		return diag.Errorf("error creating network interface: %s", err)
+	}
+
+	input := expandNetworkInterface(d)
+
+	var iface NetworkInterface
+
+	if d.Get("type").(string) == "PHYSICAL" {
+		// physical interfaces exist already, only their configuration is managed
+		if err := apiPut(ctx, m, networkInterfacePath(d.Get("name").(string)), input, &iface); err != nil {
+			return diag.Errorf("error creating network interface: %s", rollbackNetworkChanges(ctx, m, err))
+		}
+	} else {
+		input["type"] = d.Get("type").(string)
+
+		if name, ok := d.GetOk("name"); ok {
+			input["name"] = name.(string)
+		}
+
+		if err := apiPost(ctx, m, "/interface", input, &iface); err != nil {
+			return diag.Errorf("error creating network interface: %s", rollbackNetworkChanges(ctx, m, err))
+		}
+	}
+
+	if err := commitNetworkChanges(ctx, m, d.Get("checkin_timeout").(int)); err != nil {
+		return diag.Errorf("error creating network interface: %s", err)
+	}
+
+	d.SetId(iface.Id)
+
+	return resourceTrueNASNetworkInterfaceRead(ctx, d, m)
+}
+
+func resourceTrueNASNetworkInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// checkin timeout only affects future changes
+	if !d.HasChangeExcept("checkin_timeout") {
+		return resourceTrueNASNetworkInterfaceRead(ctx, d, m)
+	}
+
+	networkInterfaceMutex.Lock()
+	defer networkInterfaceMutex.Unlock()
+
+	if err := checkNoPendingNetworkChanges(ctx, m); err != nil {
+		return diag.Errorf("error updating network interface: %s", err)
+	}
+
+	if err := apiPut(ctx, m, networkInterfacePath(d.Id()), expandNetworkInterface(d), nil); err != nil {
+		return diag.Errorf("error updating network interface: %s", rollbackNetworkChanges(ctx, m, err))
+	}
+
+	if err := commitNetworkChanges(ctx, m, d.Get("checkin_timeout").(int)); err != nil {
+		return diag.Errorf("error updating network interface: %s", err)
+	}
+
+	return resourceTrueNASNetworkInterfaceRead(ctx, d, m)
+}
+
+func resourceTrueNASNetworkInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	networkInterfaceMutex.Lock()
+	defer networkInterfaceMutex.Unlock()
+
+	log.Printf("[DEBUG] Deleting TrueNAS network interface: %s", d.Id())
+
+	if err := checkNoPendingNetworkChanges(ctx, m); err != nil {
+		return diag.Errorf("error deleting network interface: %s", err)
+	}
+
+	// deleting PHYSICAL interface resets its configuration
+	if err := apiDelete(ctx, m, networkInterfacePath(d.Id()), nil); err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error deleting network interface: %s", rollbackNetworkChanges(ctx, m, err))
+	}
+
+	if err := commitNetworkChanges(ctx, m, d.Get("checkin_timeout").(int)); err != nil {
+		return diag.Errorf("error deleting network interface: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS network interface (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func networkInterfacePath(name string) string {
+	return "/interface/id/" + url.PathEscape(name)
+}
+
+// checkNoPendingNetworkChanges refuses to commit changes staged outside of Terraform, eg. in web UI
+func checkNoPendingNetworkChanges(ctx context.Context, m interface{}) error {
+	var pending bool
+
+	if err := apiGet(ctx, m, "/interface/has_pending_changes", nil, &pending); err != nil {
+		return fmt.Errorf("error checking pending network changes: %s", err)
+	}
+
+	if pending {
+		return fmt.Errorf("there are uncommitted network interface changes, commit or roll them back in TrueNAS first")
+	}
+
+	return nil
+}
+
+// rollbackNetworkChanges discards staged changes after failed request, returns original error
+func rollbackNetworkChanges(ctx context.Context, m interface{}, err error) error {
+	if rollbackErr := apiGet(ctx, m, "/interface/rollback", nil, nil); rollbackErr != nil {
+		return fmt.Errorf("%s\n(rollback of staged network changes failed: %s)", err, rollbackErr)
+	}
+
+	return err
+}
+
+// commitNetworkChanges applies staged changes with automatic rollback, and confirms them once API is reachable
+func commitNetworkChanges(ctx context.Context, m interface{}, timeout int) error {
+	input := map[string]interface{}{
+		"rollback":        true,
+		"checkin_timeout": timeout,
+	}
+
+	if err := apiPostJob(ctx, m, "/interface/commit", input, nil); err != nil {
+		return fmt.Errorf("error committing network changes: %s", rollbackNetworkChanges(ctx, m, err))
+	}
+
+	// connection may drop while interfaces are reconfigured, retry until TrueNAS rolls back
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+
+	for {
+		err := apiGet(ctx, m, "/interface/checkin", nil, nil)
+
+		if err == nil {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if rollbackErr := waitForNetworkRollback(ctx, m); rollbackErr != nil {
+				return fmt.Errorf("TrueNAS API was not reachable after network changes: %s\n(%s)", err, rollbackErr)
+			}
+
+			return fmt.Errorf("TrueNAS API was not reachable after network changes, changes were rolled back: %s", err)
+		}
+
+		log.Printf("[DEBUG] Waiting for TrueNAS API to confirm network changes: %s", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout confirming network changes, TrueNAS rolls them back: %s", ctx.Err())
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// networkRollbackGracePeriod is how long to wait past checkin_timeout for TrueNAS to finish rolling back
+const networkRollbackGracePeriod = 30 * time.Second
+
+// waitForNetworkRollback waits until TrueNAS reports no pending network changes after checkin timeout,
+// so the next network change does not race the rollback
+func waitForNetworkRollback(ctx context.Context, m interface{}) error {
+	deadline := time.Now().Add(networkRollbackGracePeriod)
+
+	for {
+		var pending bool
+
+		err := apiGet(ctx, m, "/interface/has_pending_changes", nil, &pending)
+
+		if err == nil && !pending {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("rollback of network changes could not be confirmed: %s", err)
+			}
+			return fmt.Errorf("rollback of network changes could not be confirmed, changes are still pending")
+		}
+
+		log.Printf("[DEBUG] Waiting for TrueNAS to roll back network changes")
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for rollback of network changes: %s", ctx.Err())
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+func expandNetworkInterface(d *schema.ResourceData) map[string]interface{} {
+	input := map[string]interface{}{
+		"description": d.Get("description").(string),
+		"ipv4_dhcp":   d.Get("ipv4_dhcp").(bool),
+		"ipv6_auto":   d.Get("ipv6_auto").(bool),
+		"aliases":     expandNetworkInterfaceAliases(d.Get("alias").(*schema.Set).List()),
+	}
+
+	if mtu, ok := d.GetOk("mtu"); ok {
+		input["mtu"] = mtu.(int)
+	}
+
+	switch d.Get("type").(string) {
+	case "BRIDGE":
+		input["bridge_members"] = expandStrings(d.Get("bridge_members").(*schema.Set).List())
+
+		if isConfigured(d, "stp") {
+			input["stp"] = d.Get("stp").(bool)
+		}
+	case "VLAN":
+		input["vlan_parent_interface"] = d.Get("vlan_parent_interface").(string)
+		input["vlan_tag"] = d.Get("vlan_tag").(int)
+		input["vlan_pcp"] = d.Get("vlan_pcp").(int)
+	case "LINK_AGGREGATION":
+		input["lag_protocol"] = d.Get("lag_protocol").(string)
+		input["lag_ports"] = expandStrings(d.Get("lag_ports").([]interface{}))
+	}
+
+	return input
+}
+
+func expandNetworkInterfaceAliases(l []interface{}) []NetworkInterfaceAlias {
+	res := make([]NetworkInterfaceAlias, len(l))
+
+	for i, v := range l {
+		alias := v.(map[string]interface{})
+		address := alias["address"].(string)
+
+		aliasType := "INET"
+
+		if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+			aliasType = "INET6"
+		}
+
+		res[i] = NetworkInterfaceAlias{
+			Type:    aliasType,
+			Address: address,
+			Netmask: alias["netmask"].(int),
+		}
+	}
+
+	return res
+}
+
+func flattenNetworkInterfaceAliases(l []NetworkInterfaceAlias) []interface{} {
+	res := make([]interface{}, len(l))
+
+	for i, alias := range l {
+		res[i] = map[string]interface{}{
+			"address": alias.Address,
+			"netmask": alias.Netmask,
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,52 @@
+package truenas
+
+import (
+	"context"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExpandNetworkInterfaceAliases(t *testing.T) {
+	aliases := expandNetworkInterfaceAliases([]interface{}{
+		map[string]interface{}{"address": "192.168.1.10", "netmask": 24},
+		map[string]interface{}{"address": "2001:db8::10", "netmask": 64},
+	})
+
+	assert.Equal(t, []NetworkInterfaceAlias{
+		{Type: "INET", Address: "192.168.1.10", Netmask: 24},
+		{Type: "INET6", Address: "2001:db8::10", Netmask: 64},
+	}, aliases)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"address": "192.168.1.10", "netmask": 24},
+	}, flattenNetworkInterfaceAliases(aliases[:1]))
+}
+
+func TestWaitForNetworkRollback(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		assert.Equal(t, "/interface/has_pending_changes", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if requests == 1 {
+			w.Write([]byte(`true`))
+		} else {
+			w.Write([]byte(`false`))
+		}
+	}))
+	defer server.Close()
+
+	config := api.NewConfiguration()
+	config.Servers = api.ServerConfigurations{{URL: server.URL}}
+	client := newProviderMeta(api.NewAPIClient(config))
+
+	assert.NoError(t, waitForNetworkRollback(context.Background(), client))
+	assert.Equal(t, 2, requests)
+}
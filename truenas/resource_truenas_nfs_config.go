@@ -0,0 +1,353 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+const nfsConfigID = "nfs-config"
+
+var nfsConfigAttributes = []string{
+	"servers",
+	"udp",
+	"allow_nonroot",
+	"v4_v3owner",
+	"v4_krb",
+	"v4_domain",
+	"bindip",
+	"mountd_port",
+	"rpcstatd_port",
+	"rpclockd_port",
+}
+
+// nullable port attributes, 0 means random port
+var nfsConfigPorts = []string{"mountd_port", "rpcstatd_port", "rpclockd_port"}
+
+// nfsConfigDefaults are TrueNAS defaults restored on delete, number of servers depends on CPU count and is kept as is.
+// v4 flag default depends on version, see nfsConfigDefaultV4.
+var nfsConfigDefaults = map[string]interface{}{
+	"udp":               false,
+	"allow_nonroot":     false,
+	"protocols":         []string{"NFSV3", "NFSV4"},
+	"v4_v3owner":        false,
+	"v4_krb":            false,
+	"v4_domain":         "",
+	"bindip":            []string{},
+	"mountd_port":       nil,
+	"rpcstatd_port":     nil,
+	"rpclockd_port":     nil,
+	"userd_manage_gids": false,
+	"manage_gids":       false,
+}
+
+func resourceTrueNASNFSConfig() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages global NFS service configuration. There is only one NFS configuration per system, deleting the resource restores TrueNAS defaults (except `servers`). Attributes not set are left unchanged.",
+		CreateContext: resourceTrueNASNFSConfigCreate,
+		ReadContext:   resourceTrueNASNFSConfigRead,
+		UpdateContext: resourceTrueNASNFSConfigUpdate,
+		DeleteContext: resourceTrueNASNFSConfigDelete,
+		CustomizeDiff: resourceTrueNASNFSConfigCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: nfsConfigSchema(),
+	}
+}
+
+func nfsConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"servers": &schema.Schema{
+			Description:  "Number of NFS server threads",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(1, 256),
+		},
+		"protocols": &schema.Schema{
+			Description: "Enabled protocol versions: `NFSV3`, `NFSV4`. NFSv3 can only be disabled on TrueNAS SCALE 23.10 or newer.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"NFSV3", "NFSV4"}, false),
+			},
+		},
+		"udp": &schema.Schema{
+			Description: "Serve UDP NFS clients (TrueNAS CORE)",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"allow_nonroot": &schema.Schema{
+			Description: "Allow non-root mount requests (ports above 1024)",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"v4_v3owner": &schema.Schema{
+			Description: "Use NFSv3 ownership model for NFSv4 (numeric IDs)",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"v4_krb": &schema.Schema{
+			Description: "Require Kerberos authentication for NFSv4",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"v4_domain": &schema.Schema{
+			Description: "NFSv4 ID mapping domain",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"bindip": &schema.Schema{
+			Description: "IP addresses NFS listens on, all addresses if empty",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsIPAddress,
+			},
+		},
+		"mountd_port": &schema.Schema{
+			Description:  "mountd port, `0` for random port",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(0, 65535),
+		},
+		"rpcstatd_port": &schema.Schema{
+			Description:  "rpc.statd port, `0` for random port",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(0, 65535),
+		},
+		"rpclockd_port": &schema.Schema{
+			Description:  "rpc.lockd port, `0` for random port",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(0, 65535),
+		},
+		"userd_manage_gids": &schema.Schema{
+			Description: "Resolve group membership on the server, allows users to be in more than 16 groups",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+	}
+}
+
+func resourceTrueNASNFSConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	config, err := getNFSConfig(ctx, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := flattenConfigAttributes(d, config, nfsConfigAttributes); err != nil {
+		return diag.Errorf("error reading NFS configuration: %s", err)
+	}
+
+	if err := d.Set("protocols", flattenNFSProtocols(config)); err != nil {
+		return diag.Errorf("error setting protocols: %s", err)
+	}
+
+	// renamed in TrueNAS SCALE
+	if manageGIDs, ok := config["manage_gids"].(bool); ok {
+		d.Set("userd_manage_gids", manageGIDs)
+	} else if manageGIDs, ok := config["userd_manage_gids"].(bool); ok {
+		d.Set("userd_manage_gids", manageGIDs)
+	}
+
+	return diags
+}
+
+func resourceTrueNASNFSConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateNFSConfig(ctx, m, d); err != nil {
+		return diag.Errorf("error creating NFS configuration: %s", err)
+	}
+
+	d.SetId(nfsConfigID)
+
+	return resourceTrueNASNFSConfigRead(ctx, d, m)
+}
+
+func resourceTrueNASNFSConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateNFSConfig(ctx, m, d); err != nil {
+		return diag.Errorf("error updating NFS configuration: %s", err)
+	}
+
+	return resourceTrueNASNFSConfigRead(ctx, d, m)
+}
+
+func resourceTrueNASNFSConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Restoring default TrueNAS NFS configuration")
+
+	config, err := getNFSConfig(ctx, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// some attributes are not available on every TrueNAS version
+	input := map[string]interface{}{}
+
+	for attr, value := range nfsConfigDefaults {
+		if _, ok := config[attr]; ok {
+			input[attr] = value
+		}
+	}
+
+	if _, ok := config["v4"]; ok {
+		version, err := getSystemVersion(ctx, m)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		input["v4"] = nfsConfigDefaultV4(version)
+	}
+
+	if err := apiPut(ctx, m, "/nfs", input, nil); err != nil {
+		return diag.Errorf("error deleting NFS configuration: %s", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// nfsConfigDefaultV4 returns v4 flag default, NFSv4 is disabled by default on CORE and enabled on SCALE
+func nfsConfigDefaultV4(version *systemVersion) bool {
+	return version.Scale
+}
+
+// resourceTrueNASNFSConfigCustomizeDiff rejects disabling NFSv4 while shares use security mechanisms,
+// truenas_share_nfs in turn rejects security while NFSv4 is disabled
+func resourceTrueNASNFSConfigCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("protocols") || !d.NewValueKnown("protocols") {
+		return nil
+	}
+
+	if isNFSv4Enabled(expandStrings(d.Get("protocols").(*schema.Set).List())) {
+		return nil
+	}
+
+	var shares []struct {
+		Id       int      `json:"id"`
+		Security []string `json:"security"`
+	}
+
+	if err := apiGet(ctx, m, "/sharing/nfs", nil, &shares); err != nil {
+		return fmt.Errorf("error getting NFS shares: %s", err)
+	}
+
+	for _, share := range shares {
+		if len(share.Security) > 0 {
+			return fmt.Errorf("NFSv4 cannot be disabled, NFS share %d has security set", share.Id)
+		}
+	}
+
+	return nil
+}
+
+func getNFSConfig(ctx context.Context, m interface{}) (map[string]interface{}, error) {
+	var config map[string]interface{}
+
+	if err := apiGet(ctx, m, "/nfs", nil, &config); err != nil {
+		return nil, fmt.Errorf("error getting NFS configuration: %s", err)
+	}
+
+	return config, nil
+}
+
+func updateNFSConfig(ctx context.Context, m interface{}, d *schema.ResourceData) error {
+	input := expandConfigAttributes(d, nfsConfigAttributes)
+
+	for _, attr := range nfsConfigPorts {
+		if port, ok := input[attr]; ok && port == 0 {
+			input[attr] = nil
+		}
+	}
+
+	changed := func(attr string) bool {
+		if d.IsNewResource() {
+			return isConfigured(d, attr)
+		}
+		return d.HasChange(attr)
+	}
+
+	if changed("protocols") || changed("userd_manage_gids") {
+		// attribute names depend on TrueNAS version
+		config, err := getNFSConfig(ctx, m)
+
+		if err != nil {
+			return err
+		}
+
+		if changed("protocols") {
+			protocols := expandStrings(d.Get("protocols").(*schema.Set).List())
+
+			if _, ok := config["protocols"]; ok {
+				input["protocols"] = protocols
+			} else {
+				input["v4"] = isNFSv4Enabled(protocols)
+			}
+		}
+
+		if changed("userd_manage_gids") {
+			if _, ok := config["manage_gids"]; ok {
+				input["manage_gids"] = d.Get("userd_manage_gids").(bool)
+			} else {
+				input["userd_manage_gids"] = d.Get("userd_manage_gids").(bool)
+			}
+		}
+	}
+
+	if len(input) == 0 {
+		return nil
+	}
+
+	return apiPut(ctx, m, "/nfs", input, nil)
+}
+
+// flattenNFSProtocols returns enabled protocols, older versions only have v4 flag and always serve NFSv3
+func flattenNFSProtocols(config map[string]interface{}) []interface{} {
+	if protocols, ok := config["protocols"].([]interface{}); ok {
+		return protocols
+	}
+
+	res := []interface{}{"NFSV3"}
+
+	if v4, ok := config["v4"].(bool); ok && v4 {
+		res = append(res, "NFSV4")
+	}
+
+	return res
+}
+
+func isNFSv4Enabled(protocols []string) bool {
+	for _, p := range protocols {
+		if p == "NFSV4" {
+			return true
+		}
+	}
+
+	return false
+}
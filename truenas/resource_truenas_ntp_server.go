@@ -0,0 +1,162 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strconv"
+)
+
+// NTPServer is system.ntpserver middleware object, force skips server reachability check
+type NTPServer struct {
+	Id      int    `json:"id,omitempty"`
+	Address string `json:"address"`
+	Burst   bool   `json:"burst"`
+	IBurst  bool   `json:"iburst"`
+	Prefer  bool   `json:"prefer"`
+	MinPoll int    `json:"minpoll"`
+	MaxPoll int    `json:"maxpoll"`
+	Force   bool   `json:"force,omitempty"`
+}
+
+func resourceTrueNASNTPServer() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Adds an NTP server used for system time synchronization",
+		CreateContext: resourceTrueNASNTPServerCreate,
+		ReadContext:   resourceTrueNASNTPServerRead,
+		UpdateContext: resourceTrueNASNTPServerUpdate,
+		DeleteContext: resourceTrueNASNTPServerDelete,
+		CustomizeDiff: resourceTrueNASNTPServerCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"address": &schema.Schema{
+				Description: "NTP server hostname or IP address",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"burst": &schema.Schema{
+				Description: "Send a burst of packets when server is reachable, use only with own servers",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"iburst": &schema.Schema{
+				Description: "Send a burst of packets when server is unreachable, speeds up initial synchronization",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"prefer": &schema.Schema{
+				Description: "Prefer this server over others",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"minpoll": &schema.Schema{
+				Description:  "Minimum poll interval as power of 2 seconds, eg. `6` is 64 seconds",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      6,
+				ValidateFunc: validation.IntBetween(4, 17),
+			},
+			"maxpoll": &schema.Schema{
+				Description:  "Maximum poll interval as power of 2 seconds, eg. `10` is 1024 seconds",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(4, 17),
+			},
+			"force": &schema.Schema{
+				Description: "Save server even if it is not reachable",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceTrueNASNTPServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var server NTPServer
+
+	if err := apiGet(ctx, m, "/system/ntpserver/id/"+d.Id(), nil, &server); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS NTP server (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting NTP server: %s", err)
+	}
+
+	d.Set("address", server.Address)
+	d.Set("burst", server.Burst)
+	d.Set("iburst", server.IBurst)
+	d.Set("prefer", server.Prefer)
+	d.Set("minpoll", server.MinPoll)
+	d.Set("maxpoll", server.MaxPoll)
+
+	return diags
+}
+
+func resourceTrueNASNTPServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var server NTPServer
+
+	if err := apiPost(ctx, m, "/system/ntpserver", expandNTPServer(d), &server); err != nil {
+		return diag.Errorf("error creating NTP server: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(server.Id))
+
+	return resourceTrueNASNTPServerRead(ctx, d, m)
+}
+
+func resourceTrueNASNTPServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := apiPut(ctx, m, "/system/ntpserver/id/"+d.Id(), expandNTPServer(d), nil); err != nil {
+		return diag.Errorf("error updating NTP server: %s", err)
+	}
+
+	return resourceTrueNASNTPServerRead(ctx, d, m)
+}
+
+func resourceTrueNASNTPServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS NTP server: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/system/ntpserver/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting NTP server: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS NTP server (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTrueNASNTPServerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("minpoll").(int) > d.Get("maxpoll").(int) {
+		return fmt.Errorf("minpoll must not be greater than maxpoll")
+	}
+
+	return nil
+}
+
+func expandNTPServer(d *schema.ResourceData) NTPServer {
+	return NTPServer{
+		Address: d.Get("address").(string),
+		Burst:   d.Get("burst").(bool),
+		IBurst:  d.Get("iburst").(bool),
+		Prefer:  d.Get("prefer").(bool),
+		MinPoll: d.Get("minpoll").(int),
+		MaxPoll: d.Get("maxpoll").(int),
+		Force:   d.Get("force").(bool),
+	}
+}
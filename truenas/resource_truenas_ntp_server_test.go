@@ -0,0 +1,48 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccResourceTruenasNTPServer_basic(t *testing.T) {
+	resourceName := "truenas_ntp_server.ntp"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasNTPServerConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "address", "time.cloudflare.com"),
+					resource.TestCheckResourceAttr(resourceName, "iburst", "true"),
+					resource.TestCheckResourceAttr(resourceName, "prefer", "false"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasNTPServerConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "prefer", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force"},
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasNTPServerConfig(prefer bool) string {
+	return fmt.Sprintf(`
+		resource "truenas_ntp_server" "ntp" {
+			address = "time.cloudflare.com"
+			prefer = %t
+			force = true
+		}
+	`, prefer)
+}
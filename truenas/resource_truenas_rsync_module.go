@@ -0,0 +1,215 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"regexp"
+	"strconv"
+)
+
+// RsyncModule is rsyncmod middleware object
+type RsyncModule struct {
+	Id         int      `json:"id,omitempty"`
+	Name       string   `json:"name"`
+	Comment    string   `json:"comment"`
+	Path       string   `json:"path"`
+	Enabled    bool     `json:"enabled"`
+	Mode       string   `json:"mode"`
+	MaxConn    int      `json:"maxconn"`
+	User       string   `json:"user"`
+	Group      string   `json:"group"`
+	HostsAllow []string `json:"hostsallow"`
+	HostsDeny  []string `json:"hostsdeny"`
+	Auxiliary  string   `json:"auxiliary"`
+}
+
+var rsyncModuleNameRegexp = regexp.MustCompile(`^[^/\]]+$`)
+
+func resourceTrueNASRsyncModule() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates an rsync daemon module, served by `rsync` service (TrueNAS CORE and SCALE before 23.10)",
+		CreateContext: resourceTrueNASRsyncModuleCreate,
+		ReadContext:   resourceTrueNASRsyncModuleRead,
+		UpdateContext: resourceTrueNASRsyncModuleUpdate,
+		DeleteContext: resourceTrueNASRsyncModuleDelete,
+		CustomizeDiff: resourceTrueNASRsyncModuleCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Description:  "Module name, clients connect to `rsync://host/<name>`",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(rsyncModuleNameRegexp, "name must not contain / or ]"),
+			},
+			"comment": &schema.Schema{
+				Description: "Module description",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"path": &schema.Schema{
+				Description: "Local path to share, eg. `/mnt/tank/data`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"enabled": &schema.Schema{
+				Description: "`true` if module is served",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"mode": &schema.Schema{
+				Description:  "Access mode: `RO`, `RW`, `WO`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "RO",
+				ValidateFunc: validation.StringInSlice([]string{"RO", "RW", "WO"}, false),
+			},
+			"max_connections": &schema.Schema{
+				Description:  "Maximum number of simultaneous connections, `0` for unlimited",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"user": &schema.Schema{
+				Description: "User files are transferred as",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "nobody",
+			},
+			"group": &schema.Schema{
+				Description: "Group files are transferred as",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "nogroup",
+			},
+			"hosts_allow": &schema.Schema{
+				Description: "Hosts allowed to connect, all hosts if empty",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"hosts_deny": &schema.Schema{
+				Description: "Hosts denied to connect",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"auxiliary": &schema.Schema{
+				Description: "Auxiliary rsyncd.conf module parameters",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// resourceTrueNASRsyncModuleCustomizeDiff rejects new modules on SCALE 23.10 or newer, rsyncmod was removed with rsync daemon
+func resourceTrueNASRsyncModuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	version, err := getSystemVersion(ctx, m)
+
+	if err != nil {
+		return err
+	}
+
+	if version.ScaleAtLeast(23, 10) {
+		return fmt.Errorf("rsync modules require TrueNAS CORE or SCALE before 23.10, got %s", version.Raw)
+	}
+
+	return nil
+}
+
+func resourceTrueNASRsyncModuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var module RsyncModule
+
+	if err := apiGet(ctx, m, "/rsyncmod/id/"+d.Id(), nil, &module); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS rsync module (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting rsync module: %s", err)
+	}
+
+	d.Set("name", module.Name)
+	d.Set("comment", module.Comment)
+	d.Set("path", module.Path)
+	d.Set("enabled", module.Enabled)
+	d.Set("mode", module.Mode)
+	d.Set("max_connections", module.MaxConn)
+	d.Set("user", module.User)
+	d.Set("group", module.Group)
+	d.Set("hosts_allow", flattenStringList(module.HostsAllow))
+	d.Set("hosts_deny", flattenStringList(module.HostsDeny))
+	d.Set("auxiliary", module.Auxiliary)
+
+	return diags
+}
+
+func resourceTrueNASRsyncModuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var module RsyncModule
+
+	if err := apiPost(ctx, m, "/rsyncmod", expandRsyncModule(d), &module); err != nil {
+		return diag.Errorf("error creating rsync module: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(module.Id))
+
+	return resourceTrueNASRsyncModuleRead(ctx, d, m)
+}
+
+func resourceTrueNASRsyncModuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := apiPut(ctx, m, "/rsyncmod/id/"+d.Id(), expandRsyncModule(d), nil); err != nil {
+		return diag.Errorf("error updating rsync module: %s", err)
+	}
+
+	return resourceTrueNASRsyncModuleRead(ctx, d, m)
+}
+
+func resourceTrueNASRsyncModuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS rsync module: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/rsyncmod/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting rsync module: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS rsync module (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func expandRsyncModule(d *schema.ResourceData) RsyncModule {
+	return RsyncModule{
+		Name:       d.Get("name").(string),
+		Comment:    d.Get("comment").(string),
+		Path:       d.Get("path").(string),
+		Enabled:    d.Get("enabled").(bool),
+		Mode:       d.Get("mode").(string),
+		MaxConn:    d.Get("max_connections").(int),
+		User:       d.Get("user").(string),
+		Group:      d.Get("group").(string),
+		HostsAllow: expandStrings(d.Get("hosts_allow").([]interface{})),
+		HostsDeny:  expandStrings(d.Get("hosts_deny").([]interface{})),
+		Auxiliary:  d.Get("auxiliary").(string),
+	}
+}
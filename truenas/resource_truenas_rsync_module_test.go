@@ -0,0 +1,58 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+// rsync modules are only available on TrueNAS CORE and SCALE before 23.10
+func TestAccResourceTruenasRsyncModule_basic(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	datasetName := fmt.Sprintf("%s-%s", testResourcePrefix, suffix)
+	resourceName := "truenas_rsync_module.module"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasRsyncModuleConfig(testPoolName, datasetName, "RO"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", datasetName),
+					resource.TestCheckResourceAttr(resourceName, "mode", "RO"),
+					resource.TestCheckResourceAttr(resourceName, "hosts_allow.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasRsyncModuleConfig(testPoolName, datasetName, "RW"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "mode", "RW"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasRsyncModuleConfig(pool string, datasetName string, mode string) string {
+	return fmt.Sprintf(`
+		resource "truenas_dataset" "test" {
+			name = "%s"
+			pool = "%s"
+		}
+
+		resource "truenas_rsync_module" "module" {
+			name = "%s"
+			path = truenas_dataset.test.mount_point
+			mode = "%s"
+			enabled = false
+			hosts_allow = ["192.0.2.0/24"]
+		}
+	`, datasetName, pool, datasetName, mode)
+}
@@ -0,0 +1,309 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strconv"
+)
+
+// RsyncTask is rsynctask middleware object
+type RsyncTask struct {
+	Id            int                  `json:"id,omitempty"`
+	Path          string               `json:"path"`
+	User          string               `json:"user"`
+	Mode          string               `json:"mode"`
+	RemoteHost    string               `json:"remotehost"`
+	RemotePort    *int                 `json:"remoteport"`
+	RemoteModule  string               `json:"remotemodule"`
+	RemotePath    string               `json:"remotepath"`
+	ValidateRPath bool                 `json:"validate_rpath"`
+	Direction     string               `json:"direction"`
+	Desc          string               `json:"desc"`
+	Schedule      *api.CronJobSchedule `json:"schedule,omitempty"`
+	Recursive     bool                 `json:"recursive"`
+	Times         bool                 `json:"times"`
+	Compress      bool                 `json:"compress"`
+	Archive       bool                 `json:"archive"`
+	Delete        bool                 `json:"delete"`
+	Quiet         bool                 `json:"quiet"`
+	PreservePerm  bool                 `json:"preserveperm"`
+	PreserveAttr  bool                 `json:"preserveattr"`
+	DelayUpdates  bool                 `json:"delayupdates"`
+	Extra         []string             `json:"extra"`
+	Enabled       bool                 `json:"enabled"`
+}
+
+func resourceTrueNASRsyncTask() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates an rsync task, which copies a local path to or from a remote rsync module or SSH host on schedule",
+		CreateContext: resourceTrueNASRsyncTaskCreate,
+		ReadContext:   resourceTrueNASRsyncTaskRead,
+		UpdateContext: resourceTrueNASRsyncTaskUpdate,
+		DeleteContext: resourceTrueNASRsyncTaskDelete,
+		CustomizeDiff: resourceTrueNASRsyncTaskCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"path": &schema.Schema{
+				Description: "Local path, eg. `/mnt/tank/data`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"user": &schema.Schema{
+				Description: "Account that is used to run the task, must have SSH keys for `SSH` mode",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"mode": &schema.Schema{
+				Description:  "`MODULE` connects to remote rsync daemon module, `SSH` connects over SSH",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "MODULE",
+				ValidateFunc: validation.StringInSlice([]string{"MODULE", "SSH"}, false),
+			},
+			"remote_host": &schema.Schema{
+				Description: "Remote host name or IP address, optionally with username, eg. `backup@10.0.0.5`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"remote_port": &schema.Schema{
+				Description:  "Remote SSH port, used in `SSH` mode",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      22,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"remote_module": &schema.Schema{
+				Description: "Remote rsync module name, required in `MODULE` mode",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"remote_path": &schema.Schema{
+				Description: "Remote path, required in `SSH` mode",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"validate_remote_path": &schema.Schema{
+				Description: "Verify that remote path exists when saving the task, used in `SSH` mode",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"direction": &schema.Schema{
+				Description:  "`PUSH` copies local path to remote, `PULL` copies remote to local path",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PUSH",
+				ValidateFunc: validation.StringInSlice([]string{"PUSH", "PULL"}, false),
+			},
+			"description": &schema.Schema{
+				Description: "Task description",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"recursive": &schema.Schema{
+				Description: "Recurse into subdirectories",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"times": &schema.Schema{
+				Description: "Preserve modification times",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"compress": &schema.Schema{
+				Description: "Compress data during transfer",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"archive": &schema.Schema{
+				Description: "Archive mode, equivalent to `-rlptgoD`",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"delete": &schema.Schema{
+				Description: "Delete files on destination that do not exist on source",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"quiet": &schema.Schema{
+				Description: "Suppress informational messages",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"preserve_permissions": &schema.Schema{
+				Description: "Preserve original file permissions",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"preserve_attributes": &schema.Schema{
+				Description: "Preserve extended attributes, remote rsync must support them",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"delay_updates": &schema.Schema{
+				Description: "Save temporary files and move them into place at the end of the transfer",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"extra": &schema.Schema{
+				Description: "Extra rsync arguments, eg. `--bwlimit=1000`",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"enabled": &schema.Schema{
+				Description: "`true` if task runs on schedule",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"schedule": cronScheduleSchema("Task schedule"),
+		},
+	}
+}
+
+func resourceTrueNASRsyncTaskRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var task RsyncTask
+
+	if err := apiGet(ctx, m, "/rsynctask/id/"+d.Id(), nil, &task); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS rsync task (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting rsync task: %s", err)
+	}
+
+	d.Set("path", task.Path)
+	d.Set("user", task.User)
+	d.Set("mode", task.Mode)
+	d.Set("remote_host", task.RemoteHost)
+	d.Set("remote_module", task.RemoteModule)
+	d.Set("remote_path", task.RemotePath)
+	d.Set("validate_remote_path", task.ValidateRPath)
+	d.Set("direction", task.Direction)
+	d.Set("description", task.Desc)
+	d.Set("recursive", task.Recursive)
+	d.Set("times", task.Times)
+	d.Set("compress", task.Compress)
+	d.Set("archive", task.Archive)
+	d.Set("delete", task.Delete)
+	d.Set("quiet", task.Quiet)
+	d.Set("preserve_permissions", task.PreservePerm)
+	d.Set("preserve_attributes", task.PreserveAttr)
+	d.Set("delay_updates", task.DelayUpdates)
+	d.Set("extra", flattenStringList(task.Extra))
+	d.Set("enabled", task.Enabled)
+
+	if task.RemotePort != nil {
+		d.Set("remote_port", *task.RemotePort)
+	}
+
+	if task.Schedule != nil {
+		if err := d.Set("schedule", flattenSchedule(*task.Schedule)); err != nil {
+			return diag.Errorf("error setting schedule: %s", err)
+		}
+	}
+
+	return diags
+}
+
+func resourceTrueNASRsyncTaskCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var task RsyncTask
+
+	if err := apiPost(ctx, m, "/rsynctask", expandRsyncTask(d), &task); err != nil {
+		return diag.Errorf("error creating rsync task: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(task.Id))
+
+	return resourceTrueNASRsyncTaskRead(ctx, d, m)
+}
+
+func resourceTrueNASRsyncTaskUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := apiPut(ctx, m, "/rsynctask/id/"+d.Id(), expandRsyncTask(d), nil); err != nil {
+		return diag.Errorf("error updating rsync task: %s", err)
+	}
+
+	return resourceTrueNASRsyncTaskRead(ctx, d, m)
+}
+
+func resourceTrueNASRsyncTaskDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS rsync task: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/rsynctask/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting rsync task: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS rsync task (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTrueNASRsyncTaskCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	switch d.Get("mode").(string) {
+	case "MODULE":
+		if d.NewValueKnown("remote_module") && d.Get("remote_module").(string) == "" {
+			return fmt.Errorf("remote_module is required in MODULE mode")
+		}
+	case "SSH":
+		if d.NewValueKnown("remote_path") && d.Get("remote_path").(string) == "" {
+			return fmt.Errorf("remote_path is required in SSH mode")
+		}
+	}
+
+	return nil
+}
+
+func expandRsyncTask(d *schema.ResourceData) RsyncTask {
+	remotePort := d.Get("remote_port").(int)
+
+	return RsyncTask{
+		Path:          d.Get("path").(string),
+		User:          d.Get("user").(string),
+		Mode:          d.Get("mode").(string),
+		RemoteHost:    d.Get("remote_host").(string),
+		RemotePort:    &remotePort,
+		RemoteModule:  d.Get("remote_module").(string),
+		RemotePath:    d.Get("remote_path").(string),
+		ValidateRPath: d.Get("validate_remote_path").(bool),
+		Direction:     d.Get("direction").(string),
+		Desc:          d.Get("description").(string),
+		Schedule:      expandJobSchedule(d.Get("schedule").([]interface{})),
+		Recursive:     d.Get("recursive").(bool),
+		Times:         d.Get("times").(bool),
+		Compress:      d.Get("compress").(bool),
+		Archive:       d.Get("archive").(bool),
+		Delete:        d.Get("delete").(bool),
+		Quiet:         d.Get("quiet").(bool),
+		PreservePerm:  d.Get("preserve_permissions").(bool),
+		PreserveAttr:  d.Get("preserve_attributes").(bool),
+		DelayUpdates:  d.Get("delay_updates").(bool),
+		Extra:         expandStrings(d.Get("extra").([]interface{})),
+		Enabled:       d.Get("enabled").(bool),
+	}
+}
@@ -0,0 +1,65 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccResourceTruenasRsyncTask_basic(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	datasetName := fmt.Sprintf("%s-%s", testResourcePrefix, suffix)
+	resourceName := "truenas_rsync_task.task"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasRsyncTaskConfig(testPoolName, datasetName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "mode", "MODULE"),
+					resource.TestCheckResourceAttr(resourceName, "remote_module", "backup"),
+					resource.TestCheckResourceAttr(resourceName, "delete", "false"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.hour", "3"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasRsyncTaskConfig(testPoolName, datasetName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "delete", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasRsyncTaskConfig(pool string, datasetName string, delete bool) string {
+	return fmt.Sprintf(`
+		resource "truenas_dataset" "test" {
+			name = "%s"
+			pool = "%s"
+		}
+
+		resource "truenas_rsync_task" "task" {
+			path = truenas_dataset.test.mount_point
+			user = "root"
+			remote_host = "192.0.2.10"
+			remote_module = "backup"
+			description = "%s"
+			delete = %t
+			enabled = false
+
+			schedule {
+				minute = "0"
+				hour = "3"
+			}
+		}
+	`, datasetName, pool, testResourcePrefix, delete)
+}
@@ -0,0 +1,231 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"net/url"
+	"strconv"
+)
+
+func resourceTrueNASService() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages system service startup and state. Services cannot be deleted, deleting the resource leaves the service as is.",
+		CreateContext: resourceTrueNASServiceCreate,
+		ReadContext:   resourceTrueNASServiceRead,
+		UpdateContext: resourceTrueNASServiceUpdate,
+		DeleteContext: resourceTrueNASServiceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTrueNASServiceImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Description: "Service name, eg. `cifs`, `nfs`, `ssh`, `iscsitarget`, `ups`, `snmp`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"enable": &schema.Schema{
+				Description: "Start service on boot",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"state": &schema.Schema{
+				Description:  "Service state: `RUNNING` or `STOPPED`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"RUNNING", "STOPPED"}, false),
+			},
+			"reload_triggers": &schema.Schema{
+				Description: "Arbitrary values, changing any of them reloads running service. Use to apply dependent configuration, eg. `{ smb = jsonencode(truenas_smb_config.smb) }`",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"service_id": &schema.Schema{
+				Description: "Service ID",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"pids": &schema.Schema{
+				Description: "List of pids that belong to service",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func resourceTrueNASServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	service, err := getServiceByName(ctx, m, d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if service == nil {
+		log.Printf("[WARN] TrueNAS service (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", service.Service)
+	d.Set("service_id", int(service.Id))
+
+	if service.Enable != nil {
+		d.Set("enable", *service.Enable)
+	}
+
+	if service.State != nil {
+		d.Set("state", *service.State)
+	}
+
+	d.Set("pids", flattenInt32List(service.Pids))
+
+	return diags
+}
+
+func resourceTrueNASServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+
+	service, err := getServiceByName(ctx, m, name)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if service == nil {
+		return diag.Errorf("service %s not found", name)
+	}
+
+	if enable := d.Get("enable").(bool); isConfigured(d, "enable") && (service.Enable == nil || *service.Enable != enable) {
+		if err := setServiceEnable(ctx, m, int(service.Id), enable); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if state, ok := d.GetOk("state"); ok && (service.State == nil || *service.State != state.(string)) {
+		if err := setServiceState(ctx, m, name, state.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(name)
+
+	return resourceTrueNASServiceRead(ctx, d, m)
+}
+
+func resourceTrueNASServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	name := d.Id()
+
+	if d.HasChange("enable") {
+		if err := setServiceEnable(ctx, m, d.Get("service_id").(int), d.Get("enable").(bool)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("state") {
+		if err := setServiceState(ctx, m, name, d.Get("state").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if d.HasChange("reload_triggers") && d.Get("state").(string) == "RUNNING" {
+		log.Printf("[DEBUG] Reloading TrueNAS service: %s", name)
+
+		if err := apiPostJob(ctx, m, "/service/reload", map[string]interface{}{"service": name}, nil); err != nil {
+			return diag.Errorf("error reloading service %s: %s", name, err)
+		}
+	}
+
+	return resourceTrueNASServiceRead(ctx, d, m)
+}
+
+func resourceTrueNASServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Removing TrueNAS service (%s) from state, service is left as is", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTrueNASServiceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("name", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getServiceByName returns nil if service does not exist
+func getServiceByName(ctx context.Context, m interface{}, name string) (*api.Service, error) {
+	query := url.Values{}
+	query.Set("service", name)
+
+	var services []api.Service
+
+	if err := apiGet(ctx, m, "/service", query, &services); err != nil {
+		return nil, fmt.Errorf("error getting service %s: %s", name, err)
+	}
+
+	if len(services) == 0 {
+		return nil, nil
+	}
+
+	return &services[0], nil
+}
+
+func setServiceEnable(ctx context.Context, m interface{}, id int, enable bool) error {
+	log.Printf("[DEBUG] Setting TrueNAS service (%d) enable: %t", id, enable)
+
+	if err := apiPut(ctx, m, "/service/id/"+strconv.Itoa(id), map[string]interface{}{"enable": enable}, nil); err != nil {
+		return fmt.Errorf("error updating service %d: %s", id, err)
+	}
+
+	return nil
+}
+
+// setServiceState starts or stops service, start is verified by service state as the result differs between versions
+func setServiceState(ctx context.Context, m interface{}, name string, state string) error {
+	input := map[string]interface{}{
+		"service": name,
+	}
+
+	if state == "STOPPED" {
+		log.Printf("[DEBUG] Stopping TrueNAS service: %s", name)
+
+		if err := apiPostJob(ctx, m, "/service/stop", input, nil); err != nil {
+			return fmt.Errorf("error stopping service %s: %s", name, err)
+		}
+
+		return nil
+	}
+
+	log.Printf("[DEBUG] Starting TrueNAS service: %s", name)
+
+	if err := apiPostJob(ctx, m, "/service/start", input, nil); err != nil {
+		return fmt.Errorf("error starting service %s: %s", name, err)
+	}
+
+	service, err := getServiceByName(ctx, m, name)
+
+	if err != nil {
+		return err
+	}
+
+	if service == nil || service.State == nil || *service.State != "RUNNING" {
+		return fmt.Errorf("service %s failed to start, check TrueNAS logs", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package truenas
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccResourceTruenasService_basic(t *testing.T) {
+	resourceName := "truenas_service.snmp"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasServiceConfig("RUNNING"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "snmp"),
+					resource.TestCheckResourceAttr(resourceName, "enable", "false"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+					resource.TestCheckResourceAttr("data.truenas_service.snmp", "state", "running"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasServiceConfig("STOPPED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "state", "STOPPED"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasServiceConfig(state string) string {
+	return `
+		resource "truenas_service" "snmp" {
+			name = "snmp"
+			enable = false
+			state = "` + state + `"
+		}
+
+		data "truenas_service" "snmp" {
+			name = truenas_service.snmp.name
+		}
+	`
+}
@@ -2,6 +2,7 @@ package truenas
 
 import (
 	"context"
+	"fmt"
 	api "github.com/dariusbakunas/truenas-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,6 +18,7 @@ func resourceTrueNASShareNFS() *schema.Resource {
 		ReadContext:   resourceTrueNASShareNFSRead,
 		UpdateContext: resourceTrueNASShareNFSUpdate,
 		DeleteContext: resourceTrueNASShareNFSDelete,
+		CustomizeDiff: resourceTrueNASShareNFSCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -114,10 +116,32 @@ func resourceTrueNASShareNFS() *schema.Resource {
 	}
 }
 
+// resourceTrueNASShareNFSCustomizeDiff rejects security mechanisms while NFSv4 is disabled, server would only fail on apply.
+// truenas_nfs_config in turn rejects disabling NFSv4 while any share has security set.
+func resourceTrueNASShareNFSCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("security") || len(d.Get("security").([]interface{})) == 0 {
+		return nil
+	}
+
+	config, err := getNFSConfig(ctx, m)
+
+	if err != nil {
+		return err
+	}
+
+	protocols := expandStrings(flattenNFSProtocols(config))
+
+	if !isNFSv4Enabled(protocols) {
+		return fmt.Errorf("security requires NFSv4, enable it with truenas_nfs_config protocols before setting security")
+	}
+
+	return nil
+}
+
 func resourceTrueNASShareNFSRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id, err := strconv.Atoi(d.Id())
 
 	if err != nil {
@@ -189,7 +213,7 @@ func resourceTrueNASShareNFSRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceTrueNASShareNFSCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	input := expandShareNFS(d)
 
@@ -211,7 +235,7 @@ func resourceTrueNASShareNFSCreate(ctx context.Context, d *schema.ResourceData,
 func resourceTrueNASShareNFSDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id, err := strconv.Atoi(d.Id())
 
 	if err != nil {
@@ -237,7 +261,8 @@ func resourceTrueNASShareNFSDelete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceTrueNASShareNFSUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
+
 	share := expandShareNFS(d)
 
 	id, err := strconv.Atoi(d.Id())
@@ -105,7 +105,7 @@ func testAccCheckTruenasShareNFSResourceExists(n string, share *api.ShareNFS) re
 			return fmt.Errorf("no nfs share ID is set")
 		}
 
-		client := testAccProvider.Meta().(*api.APIClient)
+		client := testAccProvider.Meta().(*providerMeta)
 
 		id, err := strconv.Atoi(rs.Primary.ID)
 
@@ -189,7 +189,7 @@ func testAccCheckTruenasShareNFSResourceAttributes(t *testing.T, n string, share
 }
 
 func testAccCheckResourceTruenasShareNFSDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*api.APIClient)
+	client := testAccProvider.Meta().(*providerMeta)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "truenas_share_nfs" {
@@ -219,7 +219,7 @@ func testAccCheckResourceTruenasShareNFSDestroy(s *terraform.State) error {
 }
 
 func testAccCheckResourceTruenasShareNFSDatasetDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*api.APIClient)
+	client := testAccProvider.Meta().(*providerMeta)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "truenas_dataset" {
@@ -241,3 +241,12 @@ func testAccCheckResourceTruenasShareNFSDatasetDestroy(s *terraform.State) error
 
 	return nil
 }
+
+func TestFlattenNFSProtocols(t *testing.T) {
+	assert.Equal(t, []interface{}{"NFSV4"}, flattenNFSProtocols(map[string]interface{}{"protocols": []interface{}{"NFSV4"}}))
+	assert.Equal(t, []interface{}{"NFSV3", "NFSV4"}, flattenNFSProtocols(map[string]interface{}{"v4": true}))
+	assert.Equal(t, []interface{}{"NFSV3"}, flattenNFSProtocols(map[string]interface{}{"v4": false}))
+
+	assert.True(t, isNFSv4Enabled([]string{"NFSV3", "NFSV4"}))
+	assert.False(t, isNFSv4Enabled([]string{"NFSV3"}))
+}
@@ -163,7 +163,7 @@ func resourceTrueNASShareSMB() *schema.Resource {
 func resourceTrueNASShareSMBRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id, err := strconv.Atoi(d.Id())
 
 	if err != nil {
@@ -242,7 +242,7 @@ func resourceTrueNASShareSMBRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceTrueNASShareSMBCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	input, err := expandShareSMB(d)
 
@@ -264,7 +264,7 @@ func resourceTrueNASShareSMBCreate(ctx context.Context, d *schema.ResourceData,
 func resourceTrueNASShareSMBDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id, err := strconv.Atoi(d.Id())
 
 	if err != nil {
@@ -290,7 +290,7 @@ func resourceTrueNASShareSMBDelete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceTrueNASShareSMBUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	share, err := expandShareSMB(d)
 
 	if err != nil {
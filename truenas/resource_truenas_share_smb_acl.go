@@ -0,0 +1,375 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// SMBShareACL is sharing.smb.getacl/setacl payload
+type SMBShareACL struct {
+	ShareName string             `json:"share_name"`
+	ShareACL  []SMBShareACLEntry `json:"share_acl"`
+}
+
+// SMBShareACLEntry principal is identified by ae_who_sid, or by name: ae_who_name object on TrueNAS CORE, ae_who_str on SCALE
+type SMBShareACLEntry struct {
+	WhoSID  *string              `json:"ae_who_sid,omitempty"`
+	WhoName *SMBShareACLEntryWho `json:"ae_who_name,omitempty"`
+	WhoStr  *string              `json:"ae_who_str,omitempty"`
+	Perm    string               `json:"ae_perm"`
+	Type    string               `json:"ae_type"`
+}
+
+type SMBShareACLEntryWho struct {
+	Domain string `json:"domain"`
+	Name   string `json:"name"`
+}
+
+var sidRegexp = regexp.MustCompile(`^S-1-\d+(-\d+)*$`)
+
+// share ACL TrueNAS creates for new shares, restored on delete
+var defaultSMBShareACL = []SMBShareACLEntry{
+	{
+		WhoSID: getStringPtr("S-1-1-0"),
+		Perm:   "FULL",
+		Type:   "ALLOWED",
+	},
+}
+
+func resourceTrueNASShareSMBACL() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages SMB share-level ACL (\"Share Permissions\" in Windows). Deleting the resource restores default ACL granting everyone full access. Filesystem ACL is not affected.",
+		CreateContext: resourceTrueNASShareSMBACLCreate,
+		ReadContext:   resourceTrueNASShareSMBACLRead,
+		UpdateContext: resourceTrueNASShareSMBACLUpdate,
+		DeleteContext: resourceTrueNASShareSMBACLDelete,
+		CustomizeDiff: resourceTrueNASShareSMBACLCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTrueNASShareSMBACLImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"share_name": &schema.Schema{
+				Description: "SMB share name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"entry": &schema.Schema{
+				Description: "Share ACL entries, either `ae_who_sid` or `ae_who_name` must be set",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ae_who_sid": &schema.Schema{
+							Description:  "SID of user or group, eg. `S-1-1-0` for everyone",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringMatch(sidRegexp, "must be a valid SID, eg. S-1-5-32-544"),
+						},
+						"ae_who_name": &schema.Schema{
+							Description: "Name of user or group, `DOMAIN\\name` for directory service accounts",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"ae_perm": &schema.Schema{
+							Description:  "Permission: `FULL`, `CHANGE` or `READ`",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"FULL", "CHANGE", "READ"}, false),
+						},
+						"ae_type": &schema.Schema{
+							Description:  "Entry type: `ALLOWED` or `DENIED`",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "ALLOWED",
+							ValidateFunc: validation.StringInSlice([]string{"ALLOWED", "DENIED"}, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTrueNASShareSMBACLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	shareName := d.Id()
+
+	exists, err := smbShareExists(ctx, m, shareName)
+
+	if err != nil {
+		return diag.Errorf("error getting SMB share ACL: %s", err)
+	}
+
+	// gracefully handle manual share deletions
+	if !exists {
+		log.Printf("[WARN] TrueNAS SMB share (%s) not found, removing share ACL from state", shareName)
+		d.SetId("")
+		return nil
+	}
+
+	var acl SMBShareACL
+
+	if err := apiPost(ctx, m, "/sharing/smb/getacl", map[string]string{"share_name": shareName}, &acl); err != nil {
+		return diag.Errorf("error getting SMB share ACL: %s", err)
+	}
+
+	d.Set("share_name", shareName)
+
+	if err := d.Set("entry", flattenSMBShareACLEntries(d.Get("entry").([]interface{}), acl.ShareACL)); err != nil {
+		return diag.Errorf("error setting SMB share ACL entries: %s", err)
+	}
+
+	return diags
+}
+
+func resourceTrueNASShareSMBACLCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	shareName := d.Get("share_name").(string)
+
+	entries, err := expandSMBShareACLEntries(ctx, m, d)
+
+	if err != nil {
+		return diag.Errorf("error creating SMB share ACL: %s", err)
+	}
+
+	if err := setSMBShareACL(ctx, m, shareName, entries); err != nil {
+		return diag.Errorf("error creating SMB share ACL: %s", err)
+	}
+
+	d.SetId(shareName)
+
+	return resourceTrueNASShareSMBACLRead(ctx, d, m)
+}
+
+func resourceTrueNASShareSMBACLUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	entries, err := expandSMBShareACLEntries(ctx, m, d)
+
+	if err != nil {
+		return diag.Errorf("error updating SMB share ACL: %s", err)
+	}
+
+	if err := setSMBShareACL(ctx, m, d.Id(), entries); err != nil {
+		return diag.Errorf("error updating SMB share ACL: %s", err)
+	}
+
+	return resourceTrueNASShareSMBACLRead(ctx, d, m)
+}
+
+func resourceTrueNASShareSMBACLDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	shareName := d.Id()
+
+	log.Printf("[DEBUG] Restoring default TrueNAS SMB share ACL: %s", shareName)
+
+	exists, err := smbShareExists(ctx, m, shareName)
+
+	if err != nil {
+		return diag.Errorf("error deleting SMB share ACL: %s", err)
+	}
+
+	// share ACL is removed together with the share
+	if exists {
+		if err := setSMBShareACL(ctx, m, shareName, defaultSMBShareACL); err != nil {
+			return diag.Errorf("error deleting SMB share ACL: %s", err)
+		}
+	}
+
+	log.Printf("[INFO] TrueNAS SMB share ACL (%s) deleted", shareName)
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTrueNASShareSMBACLImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("share_name", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func setSMBShareACL(ctx context.Context, m interface{}, shareName string, entries []SMBShareACLEntry) error {
+	input := SMBShareACL{
+		ShareName: shareName,
+		ShareACL:  entries,
+	}
+
+	return apiPost(ctx, m, "/sharing/smb/setacl", input, nil)
+}
+
+func smbShareExists(ctx context.Context, m interface{}, name string) (bool, error) {
+	c := m.(*providerMeta)
+
+	shares, _, err := c.SharingApi.ListSharesSMB(ctx).Execute()
+
+	if err != nil {
+		var body []byte
+		if apiErr, ok := err.(*api.GenericOpenAPIError); ok {
+			body = apiErr.Body()
+		}
+		return false, fmt.Errorf("error getting SMB shares: %s\n%s", err, body)
+	}
+
+	for _, share := range shares {
+		// share names are case insensitive
+		if share.Name != nil && strings.EqualFold(*share.Name, name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// resourceTrueNASShareSMBACLCustomizeDiff checks raw configuration, ae_who_sid and ae_who_name are both computed
+func resourceTrueNASShareSMBACLCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	entries := config.GetAttr("entry")
+
+	if entries.IsNull() || !entries.IsKnown() {
+		return nil
+	}
+
+	for i, entry := range entries.AsValueSlice() {
+		if !entry.IsKnown() || entry.IsNull() {
+			continue
+		}
+
+		sid := entry.GetAttr("ae_who_sid")
+		name := entry.GetAttr("ae_who_name")
+
+		// checked again once values are known
+		if !sid.IsKnown() || !name.IsKnown() {
+			continue
+		}
+
+		if sid.IsNull() == name.IsNull() {
+			return fmt.Errorf("entry %d: exactly one of ae_who_sid or ae_who_name must be set", i)
+		}
+	}
+
+	return nil
+}
+
+// expandSMBShareACLEntries uses raw configuration, ae_who_sid and ae_who_name are both computed
+// and state would otherwise keep principal of previous entry at the same position
+func expandSMBShareACLEntries(ctx context.Context, m interface{}, d *schema.ResourceData) ([]SMBShareACLEntry, error) {
+	var version *systemVersion
+
+	config := d.GetRawConfig().GetAttr("entry")
+
+	if config.IsNull() || !config.IsWhollyKnown() {
+		return nil, fmt.Errorf("entry must be known")
+	}
+
+	res := []SMBShareACLEntry{}
+
+	for _, entry := range config.AsValueSlice() {
+		e := SMBShareACLEntry{
+			Perm: entry.GetAttr("ae_perm").AsString(),
+			Type: "ALLOWED",
+		}
+
+		if entryType := entry.GetAttr("ae_type"); !entryType.IsNull() {
+			e.Type = entryType.AsString()
+		}
+
+		// exactly one is set, see resourceTrueNASShareSMBACLCustomizeDiff
+		sid := entry.GetAttr("ae_who_sid")
+		name := entry.GetAttr("ae_who_name")
+
+		if !sid.IsNull() {
+			e.WhoSID = getStringPtr(sid.AsString())
+			res = append(res, e)
+			continue
+		}
+
+		if version == nil {
+			var err error
+
+			if version, err = getSystemVersion(ctx, m); err != nil {
+				return nil, err
+			}
+		}
+
+		if version.Scale {
+			e.WhoStr = getStringPtr(name.AsString())
+		} else {
+			e.WhoName = expandSMBShareACLEntryWho(name.AsString())
+		}
+
+		res = append(res, e)
+	}
+
+	return res, nil
+}
+
+// expandSMBShareACLEntryWho splits DOMAIN\name, local accounts have empty domain
+func expandSMBShareACLEntryWho(name string) *SMBShareACLEntryWho {
+	if i := strings.Index(name, "\\"); i >= 0 {
+		return &SMBShareACLEntryWho{
+			Domain: name[:i],
+			Name:   name[i+1:],
+		}
+	}
+
+	return &SMBShareACLEntryWho{
+		Name: name,
+	}
+}
+
+// flattenSMBShareACLEntries keeps configured names of entries server reports with domain prefix, eg. local accounts on TrueNAS CORE
+func flattenSMBShareACLEntries(configured []interface{}, l []SMBShareACLEntry) []interface{} {
+	res := make([]interface{}, len(l))
+
+	for i, entry := range l {
+		e := map[string]interface{}{
+			"ae_perm": entry.Perm,
+			"ae_type": entry.Type,
+		}
+
+		if entry.WhoSID != nil {
+			e["ae_who_sid"] = *entry.WhoSID
+		}
+
+		name := ""
+
+		if entry.WhoStr != nil {
+			name = *entry.WhoStr
+		} else if entry.WhoName != nil && entry.WhoName.Domain != "" {
+			name = entry.WhoName.Domain + "\\" + entry.WhoName.Name
+		} else if entry.WhoName != nil {
+			name = entry.WhoName.Name
+		}
+
+		if i < len(configured) && configured[i] != nil {
+			configuredName, _ := configured[i].(map[string]interface{})["ae_who_name"].(string)
+
+			shortName := name[strings.Index(name, "\\")+1:]
+
+			if configuredName != "" && (strings.EqualFold(configuredName, name) || strings.EqualFold(configuredName, shortName)) {
+				name = configuredName
+			}
+		}
+
+		e["ae_who_name"] = name
+
+		res[i] = e
+	}
+
+	return res
+}
@@ -0,0 +1,98 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAccResourceTruenasShareSMBACL_basic(t *testing.T) {
+	resourceName := "truenas_share_smb_acl.acl"
+
+	suffix := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	datasetName := fmt.Sprintf("%s-%s", testResourcePrefix, suffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasShareSMBACLConfig(testPoolName, datasetName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "share_name", datasetName),
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "entry.0.ae_who_sid", "S-1-5-32-544"),
+					resource.TestCheckResourceAttr(resourceName, "entry.0.ae_perm", "FULL"),
+					resource.TestCheckResourceAttr(resourceName, "entry.1.ae_who_sid", "S-1-1-0"),
+					resource.TestCheckResourceAttr(resourceName, "entry.1.ae_perm", "READ"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFlattenSMBShareACLEntries(t *testing.T) {
+	configured := []interface{}{
+		map[string]interface{}{"ae_who_name": "jane"},
+	}
+
+	entries := []SMBShareACLEntry{
+		{
+			WhoSID:  getStringPtr("S-1-5-21-1-2-3-1000"),
+			WhoName: &SMBShareACLEntryWho{Domain: "TRUENAS", Name: "jane"},
+			Perm:    "CHANGE",
+			Type:    "ALLOWED",
+		},
+		{
+			WhoSID: getStringPtr("S-1-1-0"),
+			WhoStr: getStringPtr("Everyone"),
+			Perm:   "READ",
+			Type:   "DENIED",
+		},
+	}
+
+	res := flattenSMBShareACLEntries(configured, entries)
+
+	assert.Equal(t, "jane", res[0].(map[string]interface{})["ae_who_name"])
+	assert.Equal(t, "S-1-5-21-1-2-3-1000", res[0].(map[string]interface{})["ae_who_sid"])
+	assert.Equal(t, "Everyone", res[1].(map[string]interface{})["ae_who_name"])
+	assert.Equal(t, "DENIED", res[1].(map[string]interface{})["ae_type"])
+
+	assert.Equal(t, &SMBShareACLEntryWho{Domain: "AD", Name: "Domain Users"}, expandSMBShareACLEntryWho("AD\\Domain Users"))
+	assert.Equal(t, &SMBShareACLEntryWho{Name: "jane"}, expandSMBShareACLEntryWho("jane"))
+}
+
+func testAccCheckResourceTruenasShareSMBACLConfig(pool string, datasetName string) string {
+	return fmt.Sprintf(`
+	resource "truenas_dataset" "test" {
+		name = "%s"
+		pool = "%s"
+	}
+
+	resource "truenas_share_smb" "smb" {
+		path = truenas_dataset.test.mount_point
+		name = "%s"
+	}
+
+	resource "truenas_share_smb_acl" "acl" {
+		share_name = truenas_share_smb.smb.name
+
+		entry {
+			ae_who_sid = "S-1-5-32-544"
+			ae_perm = "FULL"
+		}
+
+		entry {
+			ae_who_sid = "S-1-1-0"
+			ae_perm = "READ"
+		}
+	}
+	`, datasetName, pool, datasetName)
+}
@@ -139,7 +139,7 @@ func testAccCheckTruenasShareSMBResourceExists(n string, share *api.ShareSMB) re
 			return fmt.Errorf("no smb share ID is set")
 		}
 
-		client := testAccProvider.Meta().(*api.APIClient)
+		client := testAccProvider.Meta().(*providerMeta)
 
 		id, err := strconv.Atoi(rs.Primary.ID)
 
@@ -258,7 +258,7 @@ func testAccCheckTruenasShareSMBResourceAttributes(t *testing.T, n string, share
 }
 
 func testAccCheckResourceTruenasShareSMBDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*api.APIClient)
+	client := testAccProvider.Meta().(*providerMeta)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "truenas_share_smb" {
@@ -288,7 +288,7 @@ func testAccCheckResourceTruenasShareSMBDestroy(s *terraform.State) error {
 }
 
 func testAccCheckResourceTruenasShareSMBDatasetDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*api.APIClient)
+	client := testAccProvider.Meta().(*providerMeta)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "truenas_dataset" {
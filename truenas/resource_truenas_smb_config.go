@@ -0,0 +1,276 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"regexp"
+)
+
+const smbConfigID = "smb-config"
+
+var smbConfigAttributes = []string{
+	"netbiosname",
+	"netbiosalias",
+	"workgroup",
+	"description",
+	"enable_smb1",
+	"unixcharset",
+	"loglevel",
+	"syslog",
+	"localmaster",
+	"guest",
+	"filemask",
+	"dirmask",
+	"admin_group",
+	"bindip",
+	"aapl_extensions",
+	"multichannel",
+	"ntlmv1_auth",
+	"auxsmbconf",
+}
+
+// smbConfigDefaults are TrueNAS defaults restored on delete, netbiosname is derived from hostname and kept as is
+var smbConfigDefaults = map[string]interface{}{
+	"netbiosalias":    []string{},
+	"workgroup":       "WORKGROUP",
+	"description":     "TrueNAS Server",
+	"enable_smb1":     false,
+	"unixcharset":     "UTF-8",
+	"loglevel":        "MINIMUM",
+	"syslog":          false,
+	"localmaster":     false,
+	"guest":           "nobody",
+	"filemask":        "",
+	"dirmask":         "",
+	"admin_group":     nil,
+	"bindip":          []string{},
+	"aapl_extensions": false,
+	"multichannel":    false,
+	"ntlmv1_auth":     false,
+	"auxsmbconf":      "",
+}
+
+var unixModeRegexp = regexp.MustCompile(`^(0?[0-7]{3})?$`)
+
+func resourceTrueNASSMBConfig() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages global SMB service configuration. There is only one SMB configuration per system, deleting the resource restores TrueNAS defaults (except `netbiosname`). Attributes not set are left unchanged.",
+		CreateContext: resourceTrueNASSMBConfigCreate,
+		ReadContext:   resourceTrueNASSMBConfigRead,
+		UpdateContext: resourceTrueNASSMBConfigUpdate,
+		DeleteContext: resourceTrueNASSMBConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: smbConfigSchema(),
+	}
+}
+
+func smbConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"netbiosname": &schema.Schema{
+			Description:  "NetBIOS name of this server",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringLenBetween(1, 15),
+		},
+		"netbiosalias": &schema.Schema{
+			Description: "Alternative NetBIOS names",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"workgroup": &schema.Schema{
+			Description: "Workgroup or domain name, must differ from `netbiosname`",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"description": &schema.Schema{
+			Description: "Server description",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"enable_smb1": &schema.Schema{
+			Description: "Allow clients to use SMB1 protocol (not recommended)",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"unixcharset": &schema.Schema{
+			Description: "Character set used internally, eg. `UTF-8`",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"loglevel": &schema.Schema{
+			Description:  "Log level: `NONE`, `MINIMUM`, `NORMAL`, `FULL` or `DEBUG`",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"NONE", "MINIMUM", "NORMAL", "FULL", "DEBUG"}, false),
+		},
+		"syslog": &schema.Schema{
+			Description: "Send SMB logs to syslog",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"localmaster": &schema.Schema{
+			Description: "Participate in local master browser elections",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"guest": &schema.Schema{
+			Description: "Account used for guest access",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"filemask": &schema.Schema{
+			Description:  "Create mask for new files, eg. `0664`. Empty to use defaults.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringMatch(unixModeRegexp, "must be an octal mode, eg. 0664"),
+		},
+		"dirmask": &schema.Schema{
+			Description:  "Create mask for new directories, eg. `0775`. Empty to use defaults.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringMatch(unixModeRegexp, "must be an octal mode, eg. 0775"),
+		},
+		"admin_group": &schema.Schema{
+			Description: "Members of this group have administrative privileges in SMB",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"bindip": &schema.Schema{
+			Description: "IP addresses SMB listens on, all addresses if empty",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsIPAddress,
+			},
+		},
+		"aapl_extensions": &schema.Schema{
+			Description: "Enable Apple SMB2/3 protocol extensions",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"multichannel": &schema.Schema{
+			Description: "Enable SMB3 multichannel (TrueNAS SCALE)",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"ntlmv1_auth": &schema.Schema{
+			Description: "Allow NTLMv1 authentication (insecure)",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"auxsmbconf": &schema.Schema{
+			Description: "Auxiliary smb4.conf parameters",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+	}
+}
+
+func resourceTrueNASSMBConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var config map[string]interface{}
+
+	if err := apiGet(ctx, m, "/smb", nil, &config); err != nil {
+		return diag.Errorf("error getting SMB configuration: %s", err)
+	}
+
+	if err := flattenConfigAttributes(d, config, smbConfigAttributes); err != nil {
+		return diag.Errorf("error reading SMB configuration: %s", err)
+	}
+
+	return diags
+}
+
+func resourceTrueNASSMBConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateSMBConfig(ctx, m, expandSMBConfig(d)); err != nil {
+		return diag.Errorf("error creating SMB configuration: %s", err)
+	}
+
+	d.SetId(smbConfigID)
+
+	return resourceTrueNASSMBConfigRead(ctx, d, m)
+}
+
+func resourceTrueNASSMBConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateSMBConfig(ctx, m, expandSMBConfig(d)); err != nil {
+		return diag.Errorf("error updating SMB configuration: %s", err)
+	}
+
+	return resourceTrueNASSMBConfigRead(ctx, d, m)
+}
+
+func resourceTrueNASSMBConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Restoring default TrueNAS SMB configuration")
+
+	var config map[string]interface{}
+
+	if err := apiGet(ctx, m, "/smb", nil, &config); err != nil {
+		return diag.Errorf("error deleting SMB configuration: %s", err)
+	}
+
+	// some attributes are not available on every TrueNAS version
+	input := map[string]interface{}{}
+
+	for attr, value := range smbConfigDefaults {
+		if _, ok := config[attr]; ok {
+			input[attr] = value
+		}
+	}
+
+	if err := updateSMBConfig(ctx, m, input); err != nil {
+		return diag.Errorf("error deleting SMB configuration: %s", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func expandSMBConfig(d *schema.ResourceData) map[string]interface{} {
+	input := expandConfigAttributes(d, smbConfigAttributes)
+
+	// nullable
+	if adminGroup, ok := input["admin_group"]; ok && adminGroup == "" {
+		input["admin_group"] = nil
+	}
+
+	return input
+}
+
+func updateSMBConfig(ctx context.Context, m interface{}, input map[string]interface{}) error {
+	if len(input) == 0 {
+		return nil
+	}
+
+	return apiPut(ctx, m, "/smb", input, nil)
+}
@@ -0,0 +1,287 @@
+package truenas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strings"
+)
+
+const sshConfigID = "ssh-config"
+
+var sshConfigAttributes = []string{
+	"bindiface",
+	"tcpport",
+	"rootlogin",
+	"adminlogin",
+	"passwordauth",
+	"kerberosauth",
+	"tcpfwd",
+	"compression",
+	"sftp_log_level",
+	"sftp_log_facility",
+	"weak_ciphers",
+	"auxparam",
+}
+
+// sshConfigDefaults are TrueNAS defaults restored on delete
+var sshConfigDefaults = map[string]interface{}{
+	"bindiface":         []string{},
+	"tcpport":           22,
+	"rootlogin":         false,
+	"adminlogin":        false,
+	"passwordauth":      true,
+	"kerberosauth":      false,
+	"tcpfwd":            false,
+	"compression":       false,
+	"sftp_log_level":    "",
+	"sftp_log_facility": "",
+	"weak_ciphers":      []string{"AES128-CBC", "NONE"},
+	"auxparam":          "",
+}
+
+// host key types, suffix of host_<type>_key_pub API attributes
+var sshHostKeyTypes = []string{"rsa", "ecdsa", "ed25519"}
+
+func resourceTrueNASSSHConfig() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages SSH service configuration. There is only one SSH configuration per system, deleting the resource restores TrueNAS defaults. Attributes not set are left unchanged.",
+		CreateContext: resourceTrueNASSSHConfigCreate,
+		ReadContext:   resourceTrueNASSSHConfigRead,
+		UpdateContext: resourceTrueNASSSHConfigUpdate,
+		DeleteContext: resourceTrueNASSSHConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: sshConfigSchema(),
+	}
+}
+
+func sshConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"bindiface": &schema.Schema{
+			Description: "Network interfaces SSH listens on, all interfaces if empty",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"tcpport": &schema.Schema{
+			Description:  "SSH port",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IsPortNumber,
+		},
+		"rootlogin": &schema.Schema{
+			Description: "Allow root to log in with password",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"adminlogin": &schema.Schema{
+			Description: "Allow admin user to log in with password (TrueNAS SCALE)",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"passwordauth": &schema.Schema{
+			Description: "Allow password authentication",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"kerberosauth": &schema.Schema{
+			Description: "Allow Kerberos authentication",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"tcpfwd": &schema.Schema{
+			Description: "Allow TCP port forwarding",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"compression": &schema.Schema{
+			Description: "Allow compression",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+		},
+		"sftp_log_level": &schema.Schema{
+			Description:  "SFTP log level: `QUIET`, `FATAL`, `ERROR`, `INFO`, `VERBOSE`, `DEBUG`, `DEBUG2`, `DEBUG3`, empty for default",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"", "QUIET", "FATAL", "ERROR", "INFO", "VERBOSE", "DEBUG", "DEBUG2", "DEBUG3"}, false),
+		},
+		"sftp_log_facility": &schema.Schema{
+			Description:  "SFTP syslog facility: `DAEMON`, `USER`, `AUTH`, `LOCAL0`-`LOCAL7`, empty for default",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"", "DAEMON", "USER", "AUTH", "LOCAL0", "LOCAL1", "LOCAL2", "LOCAL3", "LOCAL4", "LOCAL5", "LOCAL6", "LOCAL7"}, false),
+		},
+		"weak_ciphers": &schema.Schema{
+			Description: "Allowed weak ciphers: `AES128-CBC`, `NONE`",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"AES128-CBC", "NONE"}, false),
+			},
+		},
+		"auxparam": &schema.Schema{
+			Description: "Auxiliary sshd_config parameters",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"host_public_keys": &schema.Schema{
+			Description: "Host public keys by type (`rsa`, `ecdsa`, `ed25519`), in authorized_keys format without comment",
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"host_key_fingerprints": &schema.Schema{
+			Description: "Host key SHA256 fingerprints by type (`rsa`, `ecdsa`, `ed25519`), as printed by `ssh-keygen -l`",
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func resourceTrueNASSSHConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var config map[string]interface{}
+
+	if err := apiGet(ctx, m, "/ssh", nil, &config); err != nil {
+		return diag.Errorf("error getting SSH configuration: %s", err)
+	}
+
+	if err := flattenConfigAttributes(d, config, sshConfigAttributes); err != nil {
+		return diag.Errorf("error reading SSH configuration: %s", err)
+	}
+
+	publicKeys := map[string]interface{}{}
+	fingerprints := map[string]interface{}{}
+
+	for _, keyType := range sshHostKeyTypes {
+		encoded, _ := config[fmt.Sprintf("host_%s_key_pub", keyType)].(string)
+
+		if encoded == "" {
+			continue
+		}
+
+		publicKey, fingerprint, err := parseSSHHostPublicKey(encoded)
+
+		if err != nil {
+			log.Printf("[WARN] Unable to parse TrueNAS SSH %s host key: %s", keyType, err)
+			continue
+		}
+
+		publicKeys[keyType] = publicKey
+		fingerprints[keyType] = fingerprint
+	}
+
+	d.Set("host_public_keys", publicKeys)
+	d.Set("host_key_fingerprints", fingerprints)
+
+	return diags
+}
+
+func resourceTrueNASSSHConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateSSHConfig(ctx, m, expandConfigAttributes(d, sshConfigAttributes)); err != nil {
+		return diag.Errorf("error creating SSH configuration: %s", err)
+	}
+
+	d.SetId(sshConfigID)
+
+	return resourceTrueNASSSHConfigRead(ctx, d, m)
+}
+
+func resourceTrueNASSSHConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := updateSSHConfig(ctx, m, expandConfigAttributes(d, sshConfigAttributes)); err != nil {
+		return diag.Errorf("error updating SSH configuration: %s", err)
+	}
+
+	return resourceTrueNASSSHConfigRead(ctx, d, m)
+}
+
+func resourceTrueNASSSHConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Restoring default TrueNAS SSH configuration")
+
+	var config map[string]interface{}
+
+	if err := apiGet(ctx, m, "/ssh", nil, &config); err != nil {
+		return diag.Errorf("error deleting SSH configuration: %s", err)
+	}
+
+	// some attributes are not available on every TrueNAS version
+	input := map[string]interface{}{}
+
+	for attr, value := range sshConfigDefaults {
+		if _, ok := config[attr]; ok {
+			input[attr] = value
+		}
+	}
+
+	if err := updateSSHConfig(ctx, m, input); err != nil {
+		return diag.Errorf("error deleting SSH configuration: %s", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func updateSSHConfig(ctx context.Context, m interface{}, input map[string]interface{}) error {
+	if len(input) == 0 {
+		return nil
+	}
+
+	return apiPut(ctx, m, "/ssh", input, nil)
+}
+
+// parseSSHHostPublicKey decodes base64 encoded .pub file content returned by API,
+// returns key without comment and SHA256 fingerprint
+func parseSSHHostPublicKey(encoded string) (string, string, error) {
+	content, err := base64.StdEncoding.DecodeString(encoded)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	fields := strings.Fields(string(content))
+
+	if len(fields) < 2 {
+		return "", "", fmt.Errorf("unexpected public key format")
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+
+	if err != nil {
+		return "", "", err
+	}
+
+	sum := sha256.Sum256(blob)
+
+	return fields[0] + " " + fields[1], "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]), nil
+}
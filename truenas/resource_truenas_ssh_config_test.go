@@ -0,0 +1,18 @@
+package truenas
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseSSHHostPublicKey(t *testing.T) {
+	encoded := "c3NoLWVkMjU1MTkgQUFBQUMzTnphQzFsWkRJMU5URTVBQUFBSVArOC9TQkhubnZPN2kvcGFFczFBdk9FR2RUWldCNDA1UW8zMmx1aEg0THggcm9vdEB0cnVlbmFzCg=="
+
+	publicKey, fingerprint, err := parseSSHHostPublicKey(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIP+8/SBHnnvO7i/paEs1AvOEGdTZWB405Qo32luhH4Lx", publicKey)
+	assert.Equal(t, "SHA256:CKr1FztShG63tf4a83QRG2g1D4uh2E8aKpdaOQ/hDGE", fingerprint)
+
+	_, _, err = parseSSHHostPublicKey("not base64")
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strconv"
+)
+
+// StaticRoute is staticroute middleware object
+type StaticRoute struct {
+	Id          int    `json:"id,omitempty"`
+	Destination string `json:"destination"`
+	Gateway     string `json:"gateway"`
+	Description string `json:"description"`
+}
+
+func resourceTrueNASStaticRoute() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates a static route",
+		CreateContext: resourceTrueNASStaticRouteCreate,
+		ReadContext:   resourceTrueNASStaticRouteRead,
+		UpdateContext: resourceTrueNASStaticRouteUpdate,
+		DeleteContext: resourceTrueNASStaticRouteDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"destination": &schema.Schema{
+				Description:  "Destination network in CIDR notation, eg. `10.10.0.0/16`",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsCIDRNetwork(0, 128),
+			},
+			"gateway": &schema.Schema{
+				Description:  "Gateway IP address",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"description": &schema.Schema{
+				Description: "Route description",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func resourceTrueNASStaticRouteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var route StaticRoute
+
+	if err := apiGet(ctx, m, "/staticroute/id/"+d.Id(), nil, &route); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS static route (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting static route: %s", err)
+	}
+
+	d.Set("destination", route.Destination)
+	d.Set("gateway", route.Gateway)
+	d.Set("description", route.Description)
+
+	return diags
+}
+
+func resourceTrueNASStaticRouteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var route StaticRoute
+
+	if err := apiPost(ctx, m, "/staticroute", expandStaticRoute(d), &route); err != nil {
+		return diag.Errorf("error creating static route: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(route.Id))
+
+	return resourceTrueNASStaticRouteRead(ctx, d, m)
+}
+
+func resourceTrueNASStaticRouteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := apiPut(ctx, m, "/staticroute/id/"+d.Id(), expandStaticRoute(d), nil); err != nil {
+		return diag.Errorf("error updating static route: %s", err)
+	}
+
+	return resourceTrueNASStaticRouteRead(ctx, d, m)
+}
+
+func resourceTrueNASStaticRouteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS static route: %s", d.Id())
+
+	if err := apiDelete(ctx, m, "/staticroute/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting static route: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS static route (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
+
+func expandStaticRoute(d *schema.ResourceData) StaticRoute {
+	return StaticRoute{
+		Destination: d.Get("destination").(string),
+		Gateway:     d.Get("gateway").(string),
+		Description: d.Get("description").(string),
+	}
+}
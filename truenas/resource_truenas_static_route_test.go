@@ -0,0 +1,54 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccResourceTruenasStaticRoute_basic(t *testing.T) {
+	resourceName := "truenas_static_route.route"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasStaticRouteConfig("10.254.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "destination", "10.254.0.0/16"),
+					resource.TestCheckResourceAttr(resourceName, "description", testResourcePrefix),
+					resource.TestCheckResourceAttrSet(resourceName, "gateway"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasStaticRouteConfig("10.253.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "destination", "10.253.0.0/16"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckResourceTruenasStaticRouteConfig(destination string) string {
+	return fmt.Sprintf(`
+		data "truenas_system_routes" "all" {
+		}
+
+		resource "truenas_static_route" "route" {
+			destination = "%s"
+			gateway = [for r in data.truenas_system_routes.all.routes : r.gateway if r.network == "0.0.0.0"][0]
+			description = "%s"
+		}
+
+		data "truenas_static_routes" "routes" {
+			depends_on = [truenas_static_route.route]
+		}
+	`, destination, testResourcePrefix)
+}
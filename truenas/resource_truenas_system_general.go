@@ -0,0 +1,428 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const systemGeneralID = "system-general"
+
+const systemGeneralReconnectTimeout = 2 * time.Minute
+
+// systemGeneralRestartTimeout is how long to wait for web interface to go down, restart is delayed by a few seconds
+const systemGeneralRestartTimeout = 30 * time.Second
+
+// attributes with the same name in API
+var systemGeneralAttributes = []string{
+	"ui_address",
+	"ui_v6address",
+	"ui_port",
+	"ui_httpsport",
+	"ui_httpsredirect",
+	"ui_httpsprotocols",
+	"ui_allowlist",
+	"ui_consolemsg",
+	"timezone",
+	"language",
+	"kbdmap",
+	"usage_collection",
+}
+
+// changing any of these restarts web interface, which serves the API
+var systemGeneralUIAttributes = []string{
+	"ui_address",
+	"ui_v6address",
+	"ui_port",
+	"ui_httpsport",
+	"ui_httpsredirect",
+	"ui_httpsprotocols",
+	"ui_certificate",
+}
+
+func resourceTrueNASSystemGeneral() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages system general settings: web interface, localization and usage collection. There is only one configuration per system, only changed attributes are sent and attributes not set are left unchanged. Deleting the resource leaves configuration as is. Changing web interface settings restarts it, provider reconnects on the new port if it used the old one, `base_url` must be updated for subsequent runs.",
+		CreateContext: resourceTrueNASSystemGeneralCreate,
+		ReadContext:   resourceTrueNASSystemGeneralRead,
+		UpdateContext: resourceTrueNASSystemGeneralUpdate,
+		DeleteContext: resourceTrueNASSystemGeneralDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"ui_address": &schema.Schema{
+				Description: "IPv4 addresses web interface listens on, `0.0.0.0` for all",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPv4Address,
+				},
+			},
+			"ui_v6address": &schema.Schema{
+				Description: "IPv6 addresses web interface listens on, `::` for all",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPv6Address,
+				},
+			},
+			"ui_port": &schema.Schema{
+				Description:  "Web interface HTTP port",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"ui_httpsport": &schema.Schema{
+				Description:  "Web interface HTTPS port",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"ui_certificate": &schema.Schema{
+				Description: "Web interface certificate ID, eg. `truenas_certificate.ui.id`",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"ui_httpsredirect": &schema.Schema{
+				Description: "Redirect HTTP requests to HTTPS, also enables HTTP Strict Transport Security (HSTS) with one year max age",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"ui_httpsprotocols": &schema.Schema{
+				Description: "Allowed TLS versions: `TLSv1`, `TLSv1.1`, `TLSv1.2`, `TLSv1.3`",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"TLSv1", "TLSv1.1", "TLSv1.2", "TLSv1.3"}, false),
+				},
+			},
+			"ui_allowlist": &schema.Schema{
+				Description: "IP addresses and networks allowed to access web interface, all if empty (TrueNAS SCALE)",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ui_consolemsg": &schema.Schema{
+				Description: "Show console messages in web interface",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"timezone": &schema.Schema{
+				Description: "Time zone, eg. `America/Los_Angeles`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"language": &schema.Schema{
+				Description: "Web interface language code, eg. `en`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"kbdmap": &schema.Schema{
+				Description: "Console keyboard map, eg. `us`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"usage_collection": &schema.Schema{
+				Description: "Send anonymous usage statistics to iXsystems",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceTrueNASSystemGeneralRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	config, err := getSystemGeneral(ctx, m)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := flattenConfigAttributes(d, config, systemGeneralAttributes); err != nil {
+		return diag.Errorf("error reading system general configuration: %s", err)
+	}
+
+	// certificate object on most versions
+	switch v := config["ui_certificate"].(type) {
+	case map[string]interface{}:
+		if id, ok := v["id"].(float64); ok {
+			d.Set("ui_certificate", int(id))
+		}
+	case float64:
+		d.Set("ui_certificate", int(v))
+	}
+
+	return diags
+}
+
+func resourceTrueNASSystemGeneralCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags, err := updateSystemGeneral(ctx, m, d)
+
+	if err != nil {
+		return append(diags, diag.Errorf("error creating system general configuration: %s", err)...)
+	}
+
+	d.SetId(systemGeneralID)
+
+	return append(diags, resourceTrueNASSystemGeneralRead(ctx, d, m)...)
+}
+
+func resourceTrueNASSystemGeneralUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags, err := updateSystemGeneral(ctx, m, d)
+
+	if err != nil {
+		return append(diags, diag.Errorf("error updating system general configuration: %s", err)...)
+	}
+
+	return append(diags, resourceTrueNASSystemGeneralRead(ctx, d, m)...)
+}
+
+func resourceTrueNASSystemGeneralDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// resetting web interface settings could make TrueNAS unreachable
+	log.Printf("[DEBUG] Removing TrueNAS system general configuration from state, configuration is left as is")
+	d.SetId("")
+
+	return diags
+}
+
+func getSystemGeneral(ctx context.Context, m interface{}) (map[string]interface{}, error) {
+	var config map[string]interface{}
+
+	if err := apiGet(ctx, m, "/system/general", nil, &config); err != nil {
+		return nil, fmt.Errorf("error getting system general configuration: %s", err)
+	}
+
+	return config, nil
+}
+
+// updateSystemGeneral updates changed attributes, restarts web interface and reconnects if needed, returns warnings
+func updateSystemGeneral(ctx context.Context, m interface{}, d *schema.ResourceData) (diag.Diagnostics, error) {
+	var diags diag.Diagnostics
+
+	input := expandConfigAttributes(d, systemGeneralAttributes)
+
+	changed := func(attr string) bool {
+		if d.IsNewResource() {
+			return isConfigured(d, attr)
+		}
+		return d.HasChange(attr)
+	}
+
+	if changed("ui_certificate") {
+		input["ui_certificate"] = d.Get("ui_certificate").(int)
+	}
+
+	if len(input) == 0 {
+		return diags, nil
+	}
+
+	restart := false
+
+	for _, attr := range systemGeneralUIAttributes {
+		if _, ok := input[attr]; ok {
+			restart = true
+		}
+	}
+
+	// current ports are needed to find out if provider connection uses the changed one
+	current, err := getSystemGeneral(ctx, m)
+
+	if err != nil {
+		return diags, err
+	}
+
+	if err := apiPut(ctx, m, "/system/general", input, nil); err != nil {
+		return diags, err
+	}
+
+	if !restart {
+		return diags, nil
+	}
+
+	cfg := m.(*providerMeta).GetConfig()
+	baseURL := cfg.Servers[0].URL
+
+	newURL, err := systemGeneralReconnectURL(baseURL, current, input)
+
+	if err != nil {
+		return diags, err
+	}
+
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "TrueNAS web interface restarted",
+		Detail:   "Web interface settings changed, TrueNAS web interface and API were restarted. Provider connection is interrupted while the web interface restarts.",
+	})
+
+	if newURL != baseURL {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "TrueNAS web interface port changed",
+			Detail:   fmt.Sprintf("Provider reconnects to %s, update provider base_url for subsequent runs.", newURL),
+		})
+	}
+
+	if _, ok := input["ui_certificate"]; ok {
+		if u, err := url.Parse(newURL); err == nil && u.Scheme == "https" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "TrueNAS web interface certificate changed",
+				Detail:   "Provider connection must trust the new certificate, requests fail if it is not signed by a trusted certificate authority.",
+			})
+		}
+	}
+
+	if redirect, ok := input["ui_httpsredirect"].(bool); ok && redirect {
+		if u, err := url.Parse(newURL); err == nil && u.Scheme == "http" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "TrueNAS redirects HTTP to HTTPS",
+				Detail:   "Provider base_url uses HTTP, update it to HTTPS.",
+			})
+		}
+	}
+
+	log.Printf("[DEBUG] Restarting TrueNAS web interface")
+
+	// connection may be dropped before response
+	if err := apiPost(ctx, m, "/system/general/ui_restart", nil, nil); err != nil {
+		log.Printf("[WARN] TrueNAS web interface restart: %s", err)
+	}
+
+	// old server keeps responding until restart, it must not be mistaken for the restarted one
+	waitForSystemGeneralRestart(ctx, m)
+
+	cfg.Servers[0].URL = newURL
+
+	if err := waitForSystemGeneral(ctx, m); err != nil {
+		return diags, err
+	}
+
+	return diags, nil
+}
+
+// waitForSystemGeneralRestart waits until API stops responding after web interface restart request,
+// gives up after systemGeneralRestartTimeout in case restart was missed
+func waitForSystemGeneralRestart(ctx context.Context, m interface{}) {
+	ctx, cancel := context.WithTimeout(ctx, systemGeneralRestartTimeout)
+	defer cancel()
+
+	for {
+		if _, err := getSystemGeneral(ctx, m); err != nil {
+			log.Printf("[DEBUG] TrueNAS web interface is restarting: %s", err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("[WARN] TrueNAS web interface did not restart in %s", systemGeneralRestartTimeout)
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// waitForSystemGeneral waits until API responds after web interface restart
+func waitForSystemGeneral(ctx context.Context, m interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, systemGeneralReconnectTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout reconnecting to TrueNAS after web interface restart")
+		case <-time.After(apiJobPollInterval):
+		}
+
+		_, err := getSystemGeneral(ctx, m)
+
+		if err == nil {
+			return nil
+		}
+
+		log.Printf("[DEBUG] Waiting for TrueNAS web interface: %s", err)
+	}
+}
+
+// systemGeneralReconnectURL returns base URL using changed web interface port,
+// base URL is returned as is if it does not use the previous port
+func systemGeneralReconnectURL(baseURL string, current map[string]interface{}, input map[string]interface{}) (string, error) {
+	u, err := url.Parse(baseURL)
+
+	if err != nil {
+		return "", err
+	}
+
+	attr, defaultPort := "ui_port", 80
+
+	if u.Scheme == "https" {
+		attr, defaultPort = "ui_httpsport", 443
+	}
+
+	newPort, ok := input[attr].(int)
+
+	if !ok {
+		return baseURL, nil
+	}
+
+	oldPort, ok := current[attr].(float64)
+
+	if !ok {
+		return baseURL, nil
+	}
+
+	port := defaultPort
+
+	if u.Port() != "" {
+		if port, err = strconv.Atoi(u.Port()); err != nil {
+			return "", err
+		}
+	}
+
+	if port != int(oldPort) || newPort == int(oldPort) {
+		return baseURL, nil
+	}
+
+	if newPort == defaultPort {
+		u.Host = u.Hostname()
+
+		// IPv6 literals need brackets without port
+		if ip := net.ParseIP(u.Host); ip != nil && ip.To4() == nil {
+			u.Host = "[" + u.Host + "]"
+		}
+	} else {
+		u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(newPort))
+	}
+
+	return u.String(), nil
+}
@@ -0,0 +1,67 @@
+package truenas
+
+import (
+	"context"
+	api "github.com/dariusbakunas/truenas-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSystemGeneralReconnectURL(t *testing.T) {
+	current := map[string]interface{}{
+		"ui_port":      float64(80),
+		"ui_httpsport": float64(443),
+	}
+
+	tests := []struct {
+		baseURL  string
+		current  map[string]interface{}
+		input    map[string]interface{}
+		expected string
+	}{
+		// default port replaced
+		{"https://nas.local/api/v2.0", current, map[string]interface{}{"ui_httpsport": 8443}, "https://nas.local:8443/api/v2.0"},
+		{"http://10.0.0.5/api/v2.0", current, map[string]interface{}{"ui_port": 8080}, "http://10.0.0.5:8080/api/v2.0"},
+		// other scheme port changed
+		{"https://nas.local/api/v2.0", current, map[string]interface{}{"ui_port": 8080}, "https://nas.local/api/v2.0"},
+		// connection does not use web interface port, eg. reverse proxy
+		{"https://nas.local:9443/api/v2.0", current, map[string]interface{}{"ui_httpsport": 8443}, "https://nas.local:9443/api/v2.0"},
+		// back to default port
+		{"https://[fd00::5]:8443/api/v2.0", map[string]interface{}{"ui_httpsport": float64(8443)}, map[string]interface{}{"ui_httpsport": 443}, "https://[fd00::5]/api/v2.0"},
+		// no port change
+		{"https://nas.local/api/v2.0", current, map[string]interface{}{"timezone": "UTC"}, "https://nas.local/api/v2.0"},
+	}
+
+	for _, test := range tests {
+		res, err := systemGeneralReconnectURL(test.baseURL, test.current, test.input)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, res, test.baseURL)
+	}
+}
+
+func TestWaitForSystemGeneralRestart(t *testing.T) {
+	requests := 0
+
+	// old server responds twice before restart
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests > 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ui_port": 80}`))
+	}))
+	defer server.Close()
+
+	config := api.NewConfiguration()
+	config.Servers = api.ServerConfigurations{{URL: server.URL}}
+	client := newProviderMeta(api.NewAPIClient(config))
+
+	waitForSystemGeneralRestart(context.Background(), client)
+	assert.Equal(t, 3, requests)
+}
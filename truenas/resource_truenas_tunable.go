@@ -0,0 +1,180 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strconv"
+)
+
+// Tunable is tunable middleware object, create, update and delete are jobs on TrueNAS SCALE
+type Tunable struct {
+	Id      int    `json:"id,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Var     string `json:"var,omitempty"`
+	Value   string `json:"value"`
+	Comment string `json:"comment"`
+	Enabled bool   `json:"enabled"`
+}
+
+// tunableTypes maps tunable types to true if type is TrueNAS SCALE type, SYSCTL is supported by both
+var tunableTypes = map[string]bool{
+	"LOADER": false,
+	"RC":     false,
+	"UDEV":   true,
+	"ZFS":    true,
+}
+
+func resourceTrueNASTunable() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates a system tunable: sysctl, loader or rc.conf variable on TrueNAS CORE, sysctl, udev rule or ZFS module parameter on TrueNAS SCALE",
+		CreateContext: resourceTrueNASTunableCreate,
+		ReadContext:   resourceTrueNASTunableRead,
+		UpdateContext: resourceTrueNASTunableUpdate,
+		DeleteContext: resourceTrueNASTunableDelete,
+		CustomizeDiff: resourceTrueNASTunableCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Description:  "Tunable type: `SYSCTL`, `LOADER`, `RC` (TrueNAS CORE), `UDEV`, `ZFS` (TrueNAS SCALE)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SYSCTL",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"SYSCTL", "LOADER", "RC", "UDEV", "ZFS"}, false),
+			},
+			"var": &schema.Schema{
+				Description: "Variable name, eg. `net.core.somaxconn`, or udev rule name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"value": &schema.Schema{
+				Description: "Variable value, or udev rule content",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"comment": &schema.Schema{
+				Description: "Tunable description",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"enabled": &schema.Schema{
+				Description: "`true` if tunable is applied",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceTrueNASTunableCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	tunableType := d.Get("type").(string)
+
+	if _, ok := tunableTypes[tunableType]; !ok || !d.HasChange("type") {
+		return nil
+	}
+
+	version, err := getSystemVersion(ctx, m)
+
+	if err != nil {
+		return err
+	}
+
+	return checkTunableType(version, tunableType)
+}
+
+// checkTunableType returns error if tunable type is not supported by version
+func checkTunableType(version *systemVersion, tunableType string) error {
+	scale, ok := tunableTypes[tunableType]
+
+	if !ok || scale == version.Scale {
+		return nil
+	}
+
+	if scale {
+		return fmt.Errorf("%s tunables require TrueNAS SCALE, got %s", tunableType, version.Raw)
+	}
+
+	return fmt.Errorf("%s tunables are only supported on TrueNAS CORE, got %s", tunableType, version.Raw)
+}
+
+func resourceTrueNASTunableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var tunable Tunable
+
+	if err := apiGet(ctx, m, "/tunable/id/"+d.Id(), nil, &tunable); err != nil {
+		// gracefully handle manual deletions
+		if isNotFound(err) {
+			log.Printf("[WARN] TrueNAS tunable (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting tunable: %s", err)
+	}
+
+	d.Set("type", tunable.Type)
+	d.Set("var", tunable.Var)
+	d.Set("value", tunable.Value)
+	d.Set("comment", tunable.Comment)
+	d.Set("enabled", tunable.Enabled)
+
+	return diags
+}
+
+func resourceTrueNASTunableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	input := Tunable{
+		Type:    d.Get("type").(string),
+		Var:     d.Get("var").(string),
+		Value:   d.Get("value").(string),
+		Comment: d.Get("comment").(string),
+		Enabled: d.Get("enabled").(bool),
+	}
+
+	var tunable Tunable
+
+	if err := apiPostJob(ctx, m, "/tunable", input, &tunable); err != nil {
+		return diag.Errorf("error creating tunable: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(tunable.Id))
+
+	return resourceTrueNASTunableRead(ctx, d, m)
+}
+
+func resourceTrueNASTunableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// type and variable name cannot be changed
+	input := Tunable{
+		Value:   d.Get("value").(string),
+		Comment: d.Get("comment").(string),
+		Enabled: d.Get("enabled").(bool),
+	}
+
+	if err := apiPutJob(ctx, m, "/tunable/id/"+d.Id(), input, nil); err != nil {
+		return diag.Errorf("error updating tunable: %s", err)
+	}
+
+	return resourceTrueNASTunableRead(ctx, d, m)
+}
+
+func resourceTrueNASTunableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Deleting TrueNAS tunable: %s", d.Id())
+
+	if err := apiDeleteJob(ctx, m, "/tunable/id/"+d.Id(), nil); err != nil && !isNotFound(err) {
+		return diag.Errorf("error deleting tunable: %s", err)
+	}
+
+	log.Printf("[INFO] TrueNAS tunable (%s) deleted", d.Id())
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,64 @@
+package truenas
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAccResourceTruenasTunable_basic(t *testing.T) {
+	resourceName := "truenas_tunable.tunable"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckResourceTruenasTunableConfig("524288"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "SYSCTL"),
+					resource.TestCheckResourceAttr(resourceName, "var", "fs.inotify.max_user_watches"),
+					resource.TestCheckResourceAttr(resourceName, "value", "524288"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccCheckResourceTruenasTunableConfig("1048576"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value", "1048576"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// sysctl variable is validated by TrueNAS SCALE
+func testAccCheckResourceTruenasTunableConfig(value string) string {
+	return fmt.Sprintf(`
+		resource "truenas_tunable" "tunable" {
+			var = "fs.inotify.max_user_watches"
+			value = "%s"
+			comment = "%s"
+		}
+	`, value, testResourcePrefix)
+}
+
+func TestCheckTunableType(t *testing.T) {
+	core, _ := parseSystemVersion("TrueNAS-13.0-U6.1")
+	scale, _ := parseSystemVersion("TrueNAS-25.04.0")
+
+	assert.NoError(t, checkTunableType(core, "SYSCTL"))
+	assert.NoError(t, checkTunableType(core, "LOADER"))
+	assert.NoError(t, checkTunableType(core, "RC"))
+	assert.EqualError(t, checkTunableType(core, "UDEV"), "UDEV tunables require TrueNAS SCALE, got TrueNAS-13.0-U6.1")
+
+	assert.NoError(t, checkTunableType(scale, "SYSCTL"))
+	assert.NoError(t, checkTunableType(scale, "ZFS"))
+	assert.EqualError(t, checkTunableType(scale, "RC"), "RC tunables are only supported on TrueNAS CORE, got TrueNAS-25.04.0")
+}
@@ -2,11 +2,14 @@ package truenas
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"fmt"
 	api "github.com/dariusbakunas/truenas-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -18,6 +21,7 @@ func resourceTrueNASUser() *schema.Resource {
 		ReadContext:   resourceTrueNASUserRead,
 		UpdateContext: resourceTrueNASUserUpdate,
 		DeleteContext: resourceTrueNASUserDelete,
+		CustomizeDiff: resourceTrueNASUserCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -74,11 +78,42 @@ func resourceTrueNASUser() *schema.Resource {
 				Optional:    true,
 			},
 			"password": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "Specifies the account password. Cannot be read (one-way hashed on the server.)",
+				Type:          schema.TypeString,
+				Description:   "Specifies the account password. Cannot be read (one-way hashed on the server.)",
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"password_wo", "generate_password"},
+			},
+			"password_wo": &schema.Schema{
+				Type:          schema.TypeString,
+				Description:   "Specifies the account password, only its SHA-256 hash is stored in state. Sent when changed, when `password_version` changes or when password was changed outside of Terraform.",
+				Optional:      true,
+				Sensitive:     true,
+				StateFunc:     hashUserPassword,
+				ConflictsWith: []string{"generate_password"},
+			},
+			"password_version": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "Change to rotate the password: `password_wo` is sent again, or new password is generated when `generate_password` is set.",
+				Optional:    true,
+			},
+			"generate_password": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Generate random password on creation and whenever `password_version` changes, see `generated_password`.",
 				Optional:    true,
+				Default:     false,
+			},
+			"generated_password": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Generated password when `generate_password` is set.",
+				Computed:    true,
 				Sensitive:   true,
 			},
+			"password_fingerprint": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "SHA-256 fingerprint of server side password hash as of the last password change made by Terraform, used to detect password changes made outside of Terraform. Empty when password was changed outside of Terraform and will be sent again.",
+				Computed:    true,
+			},
 			"password_disabled": &schema.Schema{
 				Type:        schema.TypeBool,
 				Description: "Specifies whether the account can only use SSH keys for authentication.",
@@ -101,9 +136,43 @@ func resourceTrueNASUser() *schema.Resource {
 				Optional:    true,
 			},
 			"ssh_public_key": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "Specifies an initial SSH public key to establish on the account.",
+				Type:          schema.TypeString,
+				Description:   "Specifies an initial SSH public key to establish on the account.",
+				Optional:      true,
+				Deprecated:    "Use ssh_public_keys instead",
+				ConflictsWith: []string{"ssh_public_keys"},
+			},
+			"ssh_public_keys": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "Specifies SSH public keys (authorized_keys lines) to establish on the account. Keys are compared by key type and data, comments and options are ignored.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: hashSSHPublicKey,
+			},
+			"two_factor_enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Configures two-factor authentication secret for the user (TrueNAS SCALE 24.04 or newer). Two-factor authentication must also be enabled globally. Disabling requires TrueNAS SCALE 24.10 or newer.",
 				Optional:    true,
+				Computed:    true,
+			},
+			"two_factor_secret_version": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "Change to rotate two-factor authentication secret.",
+				Optional:    true,
+			},
+			"two_factor_secret": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Two-factor authentication (TOTP) secret.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"two_factor_provisioning_uri": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Two-factor authentication provisioning URI (`otpauth://`), can be rendered as QR code for authenticator apps.",
+				Computed:    true,
+				Sensitive:   true,
 			},
 			"sudo": &schema.Schema{
 				Description: "Specifies whether the user may invoke sudo.",
@@ -136,9 +205,14 @@ func resourceTrueNASUser() *schema.Resource {
 }
 
 func resourceTrueNASUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return readUser(ctx, d, m, false)
+}
+
+// readUser reads user into state, passwordSent is true after Create or Update sent password
+func readUser(ctx context.Context, d *schema.ResourceData, m interface{}, passwordSent bool) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -171,11 +245,14 @@ func resourceTrueNASUserRead(ctx context.Context, d *schema.ResourceData, m inte
 		groupsResp := resp.Groups
 		groups := make([]int, len(groupsResp))
 		for i := range groupsResp {
-			groupResp, _, err := c.GroupApi.GetGroup(ctx, int32(groupsResp[i])).Execute()
+			group, err := getIdentityCache(m).GroupByID(ctx, m, int(groupsResp[i]))
 			if err != nil {
-				return diag.Errorf("error getting group %s for user %s: %s", string(groupsResp[i]), d.Id(), err)
+				return diag.Errorf("error getting group %d for user %s: %s", groupsResp[i], d.Id(), err)
+			}
+			if group == nil {
+				return diag.Errorf("error getting group %d for user %s: not found", groupsResp[i], d.Id())
 			}
-			groups[i] = int(*groupResp.Gid)
+			groups[i] = group.Gid
 		}
 		d.Set("group_ids", groups)
 	}
@@ -196,6 +273,25 @@ func resourceTrueNASUserRead(ctx context.Context, d *schema.ResourceData, m inte
 		d.Set("password_disabled", *resp.PasswordDisabled)
 	}
 
+	var unixhash string
+
+	if resp.Unixhash != nil {
+		unixhash = *resp.Unixhash
+	}
+
+	fingerprint := flattenUserPasswordFingerprint(unixhash)
+	previous := d.Get("password_fingerprint").(string)
+
+	switch {
+	case passwordSent || !isUserPasswordManaged(d.Get):
+		d.Set("password_fingerprint", fingerprint)
+	case previous == "" || !isUserPasswordHash(unixhash):
+		// cleared fingerprint is kept until password is sent again, locked or disabled password is not a change
+	case previous != fingerprint:
+		log.Printf("[WARN] Password of TrueNAS user (%s) was changed outside of Terraform", d.Id())
+		d.Set("password_fingerprint", "")
+	}
+
 	if resp.Shell != nil {
 		d.Set("shell", *resp.Shell)
 	}
@@ -204,8 +300,31 @@ func resourceTrueNASUserRead(ctx context.Context, d *schema.ResourceData, m inte
 		d.Set("smb", *resp.Smb)
 	}
 
-	if resp.Sshpubkey.IsSet() && resp.Sshpubkey.Get() != nil {
-		d.Set("ssh_public_key", strings.TrimSpace(*resp.Sshpubkey.Get()))
+	if sshPublicKey := resp.Sshpubkey.Get(); sshPublicKey == nil || strings.TrimSpace(*sshPublicKey) == "" {
+		// keys removed outside of Terraform
+		d.Set("ssh_public_key", "")
+		d.Set("ssh_public_keys", []interface{}{})
+	} else if d.Get("ssh_public_key").(string) != "" {
+		d.Set("ssh_public_key", strings.TrimSpace(*sshPublicKey))
+	} else {
+		d.Set("ssh_public_keys", flattenSSHPublicKeys(d.Get("ssh_public_keys").(*schema.Set).List(), *sshPublicKey))
+	}
+
+	if configured, ok := resp.AdditionalProperties["twofactor_auth_configured"].(bool); ok {
+		d.Set("two_factor_enabled", configured)
+
+		if configured {
+			config, err := getUserTwoFactorConfig(ctx, m, resp.Username)
+			if err != nil {
+				return diag.Errorf("error getting user two-factor configuration: %s", err)
+			}
+
+			d.Set("two_factor_provisioning_uri", config.ProvisioningURI)
+			d.Set("two_factor_secret", flattenTwoFactorSecret(config.ProvisioningURI))
+		} else {
+			d.Set("two_factor_provisioning_uri", "")
+			d.Set("two_factor_secret", "")
+		}
 	}
 
 	if resp.Sudo != nil {
@@ -228,13 +347,21 @@ func resourceTrueNASUserRead(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceTrueNASUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	input, diags := expandUserCreate(ctx, d, m)
 	if diags != nil {
 		return *diags
 	}
 
+	password, generated, err := expandUserPassword(d, true)
+	if err != nil {
+		return diag.Errorf("error creating user: %s", err)
+	}
+	if password != nil {
+		input.Password = password
+	}
+
 	resp, _, err := c.UserApi.CreateUser(ctx).CreateUserParams(input).Execute()
 
 	if err != nil {
@@ -245,15 +372,25 @@ func resourceTrueNASUserCreate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.Errorf("error creating user: %s\n%s", err, body)
 	}
 
+	// user creation may also create primary group
+	getIdentityCache(m).Invalidate()
+
 	d.SetId(strconv.Itoa(int(resp)))
+	d.Set("generated_password", generated)
+
+	if d.Get("two_factor_enabled").(bool) {
+		if err := renewUserTwoFactorSecret(ctx, m, d.Get("name").(string)); err != nil {
+			return diag.Errorf("error configuring user two-factor authentication: %s", err)
+		}
+	}
 
-	return resourceTrueNASUserRead(ctx, d, m)
+	return readUser(ctx, d, m, input.Password != nil)
 }
 
 func resourceTrueNASUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -273,6 +410,8 @@ func resourceTrueNASUserDelete(ctx context.Context, d *schema.ResourceData, m in
 		return diag.Errorf("error deleting user: %s\n%s", err, body)
 	}
 
+	getIdentityCache(m).Invalidate()
+
 	log.Printf("[INFO] TrueNAS user (%s) deleted", d.Id())
 	d.SetId("")
 
@@ -280,7 +419,7 @@ func resourceTrueNASUserDelete(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceTrueNASUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -291,6 +430,15 @@ func resourceTrueNASUserUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if diags != nil {
 		return *diags
 	}
+
+	password, generated, err := expandUserPassword(d, false)
+	if err != nil {
+		return diag.Errorf("error updating user: %s", err)
+	}
+	if password != nil {
+		input.Password = password
+	}
+
 	_, _, err = c.UserApi.UpdateUser(ctx, int32(id)).UpdateUserParams(input).Execute()
 
 	if err != nil {
@@ -301,50 +449,171 @@ func resourceTrueNASUserUpdate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.Errorf("error updating user: %s\n%s", err, body)
 	}
 
-	return resourceTrueNASUserRead(ctx, d, m)
+	getIdentityCache(m).Invalidate()
+
+	if err := updateUserTwoFactor(ctx, m, d); err != nil {
+		return diag.Errorf("error configuring user two-factor authentication: %s", err)
+	}
+
+	if generated != "" || !d.Get("generate_password").(bool) {
+		d.Set("generated_password", generated)
+	}
+
+	// server side hash changes whenever password is sent
+	return readUser(ctx, d, m, input.Password != nil)
+}
+
+const userGeneratedPasswordLength = 32
+
+func resourceTrueNASUserCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && d.Get("generate_password").(bool) && (d.HasChange("password_version") || d.HasChange("generate_password")) {
+		if err := d.SetNewComputed("generated_password"); err != nil {
+			return err
+		}
+	}
+
+	// fingerprint is cleared by Read when password was changed outside of Terraform, force update to send password again
+	if d.Id() != "" && d.Get("password_fingerprint").(string) == "" && isUserPasswordManaged(d.Get) {
+		if err := d.SetNewComputed("password_fingerprint"); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("two_factor_enabled").(bool) && (d.HasChange("two_factor_enabled") || d.HasChange("two_factor_secret_version")) {
+		version, err := getSystemVersion(ctx, m)
+
+		if err != nil {
+			return err
+		}
+
+		if !version.ScaleAtLeast(24, 4) {
+			return fmt.Errorf("two_factor_enabled requires TrueNAS SCALE 24.04 or newer, got %s", version.Raw)
+		}
+
+		for _, attr := range []string{"two_factor_secret", "two_factor_provisioning_uri"} {
+			if err := d.SetNewComputed(attr); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func hashUserPassword(v interface{}) string {
+	password := v.(string)
+
+	if password == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(password)))
+}
+
+// isUserPasswordManaged returns true if password is set by Terraform, get is ResourceData or ResourceDiff Get
+func isUserPasswordManaged(get func(string) interface{}) bool {
+	return get("password").(string) != "" || get("password_wo").(string) != "" || get("generate_password").(bool)
+}
+
+// isUserPasswordHash returns false for locked or disabled password, or hash not exposed by this TrueNAS version
+func isUserPasswordHash(unixhash string) bool {
+	return unixhash != "" && !strings.HasPrefix(unixhash, "*")
+}
+
+// flattenUserPasswordFingerprint returns SHA-256 fingerprint of server side password hash, never empty
+func flattenUserPasswordFingerprint(unixhash string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(unixhash)))
+}
+
+// expandUserPassword returns password to send, and generated password if it was generated,
+// nil if password is unchanged
+func expandUserPassword(d *schema.ResourceData, create bool) (*string, string, error) {
+	// password_fingerprint is cleared when password was changed outside of Terraform
+	previousFingerprint, _ := d.GetChange("password_fingerprint")
+	drift := !create && previousFingerprint.(string) == ""
+	rotate := create || d.HasChange("password_version")
+
+	if d.Get("generate_password").(bool) {
+		// send current generated password again if it was changed outside of Terraform
+		if drift && !rotate && !d.HasChange("generate_password") {
+			generated := d.Get("generated_password").(string)
+			return &generated, generated, nil
+		}
+
+		if !rotate && !d.HasChange("generate_password") {
+			return nil, "", nil
+		}
+
+		generated, err := generatePassword(userGeneratedPasswordLength)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return &generated, generated, nil
+	}
+
+	if password, ok := d.GetOk("password"); ok && drift {
+		return getStringPtr(password.(string)), "", nil
+	}
+
+	if !rotate && !drift && !d.HasChange("password_wo") {
+		return nil, "", nil
+	}
+
+	// password_wo state only has a hash, plaintext is only available in configuration
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil, "", nil
+	}
+
+	password := config.GetAttr("password_wo")
+	if password.IsNull() || !password.IsKnown() {
+		return nil, "", nil
+	}
+
+	return getStringPtr(password.AsString()), "", nil
 }
 
 type APIGroup struct {
-	Id           int
-	Gid          int
-	Group        string
-	Builtin      bool
-	Sudo         bool
-	SudoNopasswd bool
-	SudoCommands []string
-	Smb          bool
-	Users        []int
-	Local        bool
-	IdTypeBoth   bool
+	Id           int      `json:"id"`
+	Gid          int      `json:"gid"`
+	Group        string   `json:"group"`
+	Builtin      bool     `json:"builtin"`
+	Sudo         bool     `json:"sudo"`
+	SudoNopasswd bool     `json:"sudo_nopasswd"`
+	SudoCommands []string `json:"sudo_commands"`
+	Smb          bool     `json:"smb"`
+	Users        []int    `json:"users"`
+	Local        bool     `json:"local"`
+	IdTypeBoth   bool     `json:"id_type_both"`
 }
 
 func enumerateGroups(ctx context.Context, m interface{}) ([]APIGroup, *diag.Diagnostics) {
-	c := m.(*api.APIClient)
-
-	// It seems the only way to map gid to group.id is to enumerate the whole list :(
-	groupsResponse, err := c.GroupApi.ListGroups(ctx).Execute()
+	groups, err := getIdentityCache(m).Groups(ctx, m)
 	if err != nil {
-		diags := diag.Errorf("error getting groups: %s", err)
+		diags := diag.FromErr(err)
 		return []APIGroup{}, &diags
 	}
 
-	var groupsList []APIGroup
-	decoder := json.NewDecoder(groupsResponse.Body)
-	err = decoder.Decode(&groupsList)
+	return groups, nil
+}
+
+// lookupGroupByGID maps unix GID to TrueNAS group
+func lookupGroupByGID(ctx context.Context, m interface{}, gid int) (*APIGroup, *diag.Diagnostics) {
+	group, err := getIdentityCache(m).GroupByGID(ctx, m, gid)
 	if err != nil {
-		diags := diag.Errorf("error decoding groups response: %s", err)
-		return []APIGroup{}, &diags
+		diags := diag.FromErr(err)
+		return nil, &diags
+	}
+	if group == nil {
+		diags := diag.Errorf("error getting group %s: not found", strconv.Itoa(gid))
+		return nil, &diags
 	}
 
-	return groupsList, nil
+	return group, nil
 }
 
 func expandUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (api.CreateUserParams, *diag.Diagnostics) {
-	apiGroups, diags := enumerateGroups(ctx, m)
-	if diags != nil {
-		return api.CreateUserParams{}, diags
-	}
-
 	input := api.CreateUserParams{
 		Username: d.Get("name").(string),
 		FullName: d.Get("full_name").(string),
@@ -355,18 +624,10 @@ func expandUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 	}
 
 	if group, ok := d.GetOk("gid"); ok {
-		groupLookup := group.(int)
 		// TrueNAS group ID, not bsd group ID
-		var foundGroup *APIGroup = nil
-		for _, x := range apiGroups {
-			if x.Gid == groupLookup {
-				foundGroup = &x
-				break
-			}
-		}
-		if foundGroup == nil {
-			diags := diag.Errorf("error getting group %s: not found", strconv.Itoa(groupLookup))
-			return api.CreateUserParams{}, &diags
+		foundGroup, diags := lookupGroupByGID(ctx, m, group.(int))
+		if diags != nil {
+			return api.CreateUserParams{}, diags
 		}
 		input.Group = getInt32Ptr(int32(foundGroup.Id))
 	}
@@ -433,23 +694,19 @@ func expandUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 		input.Sshpubkey.Set(getStringPtr(strings.TrimSpace(sshPublicKey.(string))))
 	}
 
+	if sshPublicKeys, ok := d.GetOk("ssh_public_keys"); ok {
+		input.Sshpubkey.Set(getStringPtr(expandSSHPublicKeys(sshPublicKeys.(*schema.Set).List())))
+	}
+
 	if groupIds, ok := d.GetOk("group_ids"); ok {
 		// Convert int group IDs to int32 for CreateUserParams model, mapping unix GID to TrueNAS middleware ID
 		intGroupIds := groupIds.(*schema.Set).List()
 		int32GroupIds := make([]int32, len(intGroupIds))
 		for i := range intGroupIds {
 			// TrueNAS group ID, not bsd group ID
-			groupLookup := intGroupIds[i].(int)
-			var foundGroup *APIGroup = nil
-			for _, x := range apiGroups {
-				if x.Gid == groupLookup {
-					foundGroup = &x
-					break
-				}
-			}
-			if foundGroup == nil {
-				diags := diag.Errorf("error getting group %s: not found", strconv.Itoa(groupLookup))
-				return api.CreateUserParams{}, &diags
+			foundGroup, diags := lookupGroupByGID(ctx, m, intGroupIds[i].(int))
+			if diags != nil {
+				return api.CreateUserParams{}, diags
 			}
 			int32GroupIds[i] = int32(foundGroup.Id)
 		}
@@ -460,10 +717,6 @@ func expandUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 }
 
 func expandUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (api.UpdateUserParams, *diag.Diagnostics) {
-	apiGroups, diags := enumerateGroups(ctx, m)
-	if diags != nil {
-		return api.UpdateUserParams{}, diags
-	}
 	input := api.UpdateUserParams{}
 
 	if d.HasChange("uid") {
@@ -475,18 +728,10 @@ func expandUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 	}
 
 	if d.HasChange("gid") {
-		groupLookup := d.Get("gid").(int)
 		// TrueNAS group ID, not bsd group ID
-		var foundGroup *APIGroup = nil
-		for _, x := range apiGroups {
-			if x.Gid == groupLookup {
-				foundGroup = &x
-				break
-			}
-		}
-		if foundGroup == nil {
-			diags := diag.Errorf("error getting group %s: not found", strconv.Itoa(groupLookup))
-			return api.UpdateUserParams{}, &diags
+		foundGroup, diags := lookupGroupByGID(ctx, m, d.Get("gid").(int))
+		if diags != nil {
+			return api.UpdateUserParams{}, diags
 		}
 		input.Group = getInt32Ptr(int32(foundGroup.Id))
 	}
@@ -552,23 +797,19 @@ func expandUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 		input.Sshpubkey.Set(getStringPtr(strings.TrimSpace(sshPublicKey.(string))))
 	}
 
+	if d.HasChange("ssh_public_keys") {
+		input.Sshpubkey.Set(getStringPtr(expandSSHPublicKeys(d.Get("ssh_public_keys").(*schema.Set).List())))
+	}
+
 	if d.HasChange("group_ids") {
 		// Convert int group IDs to int32 for UpdateUserParams model, mapping unix GID to TrueNAS middleware ID
 		intGroupIds := d.Get("group_ids").(*schema.Set).List()
 		int32GroupIds := make([]int32, len(intGroupIds))
 		for i := range intGroupIds {
 			// TrueNAS group ID, not bsd group ID
-			groupLookup := intGroupIds[i].(int)
-			var foundGroup *APIGroup = nil
-			for _, x := range apiGroups {
-				if x.Gid == groupLookup {
-					foundGroup = &x
-					break
-				}
-			}
-			if foundGroup == nil {
-				diags := diag.Errorf("error getting group %s: not found", strconv.Itoa(groupLookup))
-				return api.UpdateUserParams{}, &diags
+			foundGroup, diags := lookupGroupByGID(ctx, m, intGroupIds[i].(int))
+			if diags != nil {
+				return api.UpdateUserParams{}, diags
 			}
 			int32GroupIds[i] = int32(foundGroup.Id)
 		}
@@ -577,3 +818,115 @@ func expandUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	return input, nil
 }
+
+// normalizeSSHPublicKey returns key type and base64 data of authorized_keys line, without options and comment
+func normalizeSSHPublicKey(line string) string {
+	fields := strings.Fields(line)
+
+	for i, field := range fields {
+		if i+1 < len(fields) && (strings.HasPrefix(field, "ssh-") || strings.HasPrefix(field, "ecdsa-") || strings.HasPrefix(field, "sk-")) {
+			return field + " " + fields[i+1]
+		}
+	}
+
+	return strings.Join(fields, " ")
+}
+
+func hashSSHPublicKey(v interface{}) int {
+	return schema.HashString(normalizeSSHPublicKey(v.(string)))
+}
+
+func expandSSHPublicKeys(keys []interface{}) string {
+	lines := make([]string, len(keys))
+
+	for i, key := range keys {
+		lines[i] = strings.TrimSpace(key.(string))
+	}
+
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\n")
+}
+
+// flattenSSHPublicKeys returns keys from authorized_keys content, keeping configured
+// representation for keys that only differ by comment or options
+func flattenSSHPublicKeys(configured []interface{}, authorizedKeys string) []interface{} {
+	known := make(map[string]string, len(configured))
+
+	for _, key := range configured {
+		known[normalizeSSHPublicKey(key.(string))] = key.(string)
+	}
+
+	var res []interface{}
+
+	for _, line := range strings.Split(authorizedKeys, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if key, ok := known[normalizeSSHPublicKey(line)]; ok {
+			res = append(res, key)
+		} else {
+			res = append(res, line)
+		}
+	}
+
+	return res
+}
+
+// userTwoFactorConfig is user.twofactor_config response
+type userTwoFactorConfig struct {
+	ProvisioningURI  string `json:"provisioning_uri"`
+	SecretConfigured bool   `json:"secret_configured"`
+	Interval         int    `json:"interval"`
+	OTPDigits        int    `json:"otp_digits"`
+}
+
+func getUserTwoFactorConfig(ctx context.Context, m interface{}, username string) (*userTwoFactorConfig, error) {
+	var config userTwoFactorConfig
+
+	if err := apiPost(ctx, m, "/user/twofactor_config", username, &config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// renewUserTwoFactorSecret generates new secret, which also enables two-factor authentication for the user
+func renewUserTwoFactorSecret(ctx context.Context, m interface{}, username string) error {
+	input := map[string]interface{}{
+		"username": username,
+	}
+
+	return apiPost(ctx, m, "/user/renew_2fa_secret", input, nil)
+}
+
+func updateUserTwoFactor(ctx context.Context, m interface{}, d *schema.ResourceData) error {
+	username := d.Get("name").(string)
+
+	if d.Get("two_factor_enabled").(bool) {
+		if d.HasChange("two_factor_enabled") || d.HasChange("two_factor_secret_version") {
+			return renewUserTwoFactorSecret(ctx, m, username)
+		}
+		return nil
+	}
+
+	if d.HasChange("two_factor_enabled") {
+		return apiPost(ctx, m, "/user/unset_2fa_secret", username, nil)
+	}
+
+	return nil
+}
+
+// flattenTwoFactorSecret returns secret parameter of otpauth:// provisioning URI
+func flattenTwoFactorSecret(provisioningURI string) string {
+	u, err := url.Parse(provisioningURI)
+
+	if err != nil {
+		return ""
+	}
+
+	return u.Query().Get("secret")
+}
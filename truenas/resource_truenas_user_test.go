@@ -0,0 +1,55 @@
+package truenas
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestHashUserPassword(t *testing.T) {
+	assert.Equal(t, "", hashUserPassword(""))
+	assert.Equal(t, "sha256:5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8", hashUserPassword("password"))
+}
+
+func TestFlattenUserPasswordFingerprint(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", flattenUserPasswordFingerprint(""))
+	assert.Equal(t, "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8", flattenUserPasswordFingerprint("password"))
+
+	assert.False(t, isUserPasswordHash(""))
+	assert.False(t, isUserPasswordHash("*"))
+	assert.True(t, isUserPasswordHash("$6$salt$hash"))
+}
+
+func TestGeneratePassword(t *testing.T) {
+	a, err := generatePassword(userGeneratedPasswordLength)
+	assert.NoError(t, err)
+	assert.Len(t, a, userGeneratedPasswordLength)
+
+	for _, c := range a {
+		assert.True(t, strings.ContainsRune(passwordCharset, c))
+	}
+
+	b, err := generatePassword(userGeneratedPasswordLength)
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, b)
+}
+
+func TestNormalizeSSHPublicKey(t *testing.T) {
+	assert.Equal(t, "ssh-ed25519 AAAAC3Nza", normalizeSSHPublicKey("ssh-ed25519 AAAAC3Nza jane@laptop"))
+	assert.Equal(t, "ssh-ed25519 AAAAC3Nza", normalizeSSHPublicKey(`from="10.0.0.0/8" ssh-ed25519 AAAAC3Nza   jane@desktop`))
+	assert.Equal(t, "ecdsa-sha2-nistp256 AAAAE2Vj", normalizeSSHPublicKey("  ecdsa-sha2-nistp256 AAAAE2Vj\n"))
+	assert.Equal(t, hashSSHPublicKey("ssh-rsa AAAAB3 a"), hashSSHPublicKey("ssh-rsa AAAAB3 b"))
+}
+
+func TestFlattenSSHPublicKeys(t *testing.T) {
+	configured := []interface{}{"ssh-ed25519 AAAAC3Nza jane@laptop"}
+	authorizedKeys := "ssh-ed25519 AAAAC3Nza jane@laptop-renamed\n\n# comment\nssh-rsa AAAAB3 ci\n"
+
+	assert.Equal(t, []interface{}{"ssh-ed25519 AAAAC3Nza jane@laptop", "ssh-rsa AAAAB3 ci"}, flattenSSHPublicKeys(configured, authorizedKeys))
+	assert.Nil(t, flattenSSHPublicKeys(nil, ""))
+}
+
+func TestFlattenTwoFactorSecret(t *testing.T) {
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", flattenTwoFactorSecret("otpauth://totp/jane@truenas?secret=JBSWY3DPEHPK3PXP&issuer=TrueNAS"))
+	assert.Equal(t, "", flattenTwoFactorSecret(""))
+}
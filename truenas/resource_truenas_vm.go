@@ -2,10 +2,13 @@ package truenas
 
 import (
 	"context"
+	"fmt"
 	api "github.com/dariusbakunas/truenas-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"sort"
 	"strconv"
 )
 
@@ -15,6 +18,7 @@ func resourceTrueNASVM() *schema.Resource {
 		CreateContext: resourceTrueNASVMCreate,
 		DeleteContext: resourceTrueNASVMDelete,
 		UpdateContext: resourceTrueNASVMUpdate,
+		CustomizeDiff: resourceTrueNASVMCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -84,6 +88,66 @@ func resourceTrueNASVM() *schema.Resource {
 				Optional:    true,
 				Default:     "536870912", // 512MiB
 			},
+			"min_memory": &schema.Schema{
+				Description:  "Minimum memory in bytes the VM can be ballooned down to when host is under memory pressure, must be less than `memory`. SCALE 22.12+ only.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(256 * 1024 * 1024),
+			},
+			"cpu_mode": &schema.Schema{
+				Description:  "CPU mode: `CUSTOM`, `HOST-MODEL` or `HOST-PASSTHROUGH`. SCALE only.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"CUSTOM", "HOST-MODEL", "HOST-PASSTHROUGH"}, false),
+			},
+			"cpu_model": &schema.Schema{
+				Description: "CPU model to emulate, only valid when `cpu_mode` is `CUSTOM`, see `truenas_vm_cpu_models` data source for supported models. SCALE only.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"cpuset": &schema.Schema{
+				Description:  "Host CPUs the VM vCPUs are pinned to, eg. `0-3,8`. SCALE 22.12+ only.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(cpusetRegexp, "must be a list of CPU IDs or ranges, eg. `0-3,8`"),
+			},
+			"nodeset": &schema.Schema{
+				Description:  "Host NUMA nodes the VM memory is allocated from, eg. `0-1`. SCALE 22.12+ only.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(cpusetRegexp, "must be a list of NUMA node IDs or ranges, eg. `0-1`"),
+			},
+			"hide_from_msr": &schema.Schema{
+				Description: "Hide KVM hypervisor from standard MSR based discovery, useful for GPU passthrough. SCALE only.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"ensure_display_device": &schema.Schema{
+				Description: "Ensure guest always has access to a video device, set to `false` for GPU passthrough without display device. SCALE only.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"hyperv_enlightenments": &schema.Schema{
+				Description: "Enable Hyper-V enlightenments to improve Windows guest performance. SCALE 23.10+ only.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"arch_type": &schema.Schema{
+				Description: "Guest architecture type, system chooses reasonable default based on host if not set. SCALE only.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"machine_type": &schema.Schema{
+				Description: "Guest machine type based on `arch_type`, system chooses reasonable default if not set. SCALE only.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
 			"device": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -146,7 +210,7 @@ func resourceTrueNASVM() *schema.Resource {
 }
 
 func resourceTrueNASVMRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	id, err := strconv.Atoi(d.Id())
 
@@ -198,6 +262,11 @@ func resourceTrueNASVMRead(ctx context.Context, d *schema.ResourceData, m interf
 		d.Set("time", *resp.Time)
 	}
 
+	// attributes not covered by SDK VM model
+	for key, value := range flattenVMAdditionalProperties(resp.AdditionalProperties) {
+		d.Set(key, value)
+	}
+
 	if resp.Devices != nil {
 		if err := d.Set("device", flattenVMDevices(resp.Devices)); err != nil {
 			return diag.Errorf("error setting VM devices: %s", err)
@@ -215,7 +284,7 @@ func resourceTrueNASVMRead(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceTrueNASVMCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	input := api.CreateVMParams{
 		Name: getStringPtr(d.Get("name").(string)),
@@ -257,6 +326,30 @@ func resourceTrueNASVMCreate(ctx context.Context, d *schema.ResourceData, m inte
 		input.Memory = getInt64Ptr(int64(memory.(int)))
 	}
 
+	if cpuMode, ok := d.GetOk("cpu_mode"); ok {
+		input.CpuMode = getStringPtr(cpuMode.(string))
+	}
+
+	if cpuModel, ok := d.GetOk("cpu_model"); ok {
+		input.CpuModel.Set(getStringPtr(cpuModel.(string)))
+	}
+
+	if hideFromMsr, ok := d.GetOkExists("hide_from_msr"); ok {
+		input.HideFromMsr = getBoolPtr(hideFromMsr.(bool))
+	}
+
+	if ensureDisplayDevice, ok := d.GetOkExists("ensure_display_device"); ok {
+		input.EnsureDisplayDevice = getBoolPtr(ensureDisplayDevice.(bool))
+	}
+
+	if archType, ok := d.GetOk("arch_type"); ok {
+		input.ArchType.Set(getStringPtr(archType.(string)))
+	}
+
+	if machineType, ok := d.GetOk("machine_type"); ok {
+		input.MachineType.Set(getStringPtr(machineType.(string)))
+	}
+
 	if devices, ok := d.GetOk("device"); ok {
 		dv, err := expandVMDevice(devices.(*schema.Set).List())
 
@@ -278,11 +371,30 @@ func resourceTrueNASVMCreate(ctx context.Context, d *schema.ResourceData, m inte
 	}
 
 	d.SetId(strconv.Itoa(int(resp.Id)))
+
+	// CreateVMParams does not support all VM attributes, set remaining ones with follow-up update
+	if props := expandVMAdditionalProperties(d, false); len(props) > 0 {
+		update := api.UpdateVMParams{
+			Name:                 getStringPtr(resp.Name),
+			AdditionalProperties: props,
+		}
+
+		_, _, err = c.VmApi.UpdateVM(ctx, resp.Id).UpdateVMParams(update).Execute()
+
+		if err != nil {
+			var body []byte
+			if apiErr, ok := err.(*api.GenericOpenAPIError); ok {
+				body = apiErr.Body()
+			}
+			return diag.Errorf("error creating VM: %s\n%s", err, body)
+		}
+	}
+
 	return resourceTrueNASVMRead(ctx, d, m)
 }
 
 func resourceTrueNASVMDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	id, err := strconv.Atoi(d.Id())
 
@@ -307,7 +419,7 @@ func resourceTrueNASVMDelete(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceTrueNASVMUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	id, err := strconv.Atoi(d.Id())
 
@@ -355,6 +467,32 @@ func resourceTrueNASVMUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		input.Memory = getInt64Ptr(int64(d.Get("memory").(int)))
 	}
 
+	if d.HasChange("cpu_mode") {
+		input.CpuMode = getStringPtr(d.Get("cpu_mode").(string))
+	}
+
+	if d.HasChange("cpu_model") {
+		input.CpuModel.Set(getNullableStringPtr(d.Get("cpu_model").(string)))
+	}
+
+	if d.HasChange("hide_from_msr") {
+		input.HideFromMsr = getBoolPtr(d.Get("hide_from_msr").(bool))
+	}
+
+	if d.HasChange("ensure_display_device") {
+		input.EnsureDisplayDevice = getBoolPtr(d.Get("ensure_display_device").(bool))
+	}
+
+	if d.HasChange("arch_type") {
+		input.ArchType.Set(getNullableStringPtr(d.Get("arch_type").(string)))
+	}
+
+	if d.HasChange("machine_type") {
+		input.MachineType.Set(getNullableStringPtr(d.Get("machine_type").(string)))
+	}
+
+	input.AdditionalProperties = expandVMAdditionalProperties(d, true)
+
 	if d.HasChange("device") {
 		input.Devices, err = expandVMDeviceForUpdate(d.Get("device").(*schema.Set).List(), getInt32Ptr(int32(id)))
 
@@ -387,6 +525,139 @@ func resourceTrueNASVMUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	return resourceTrueNASVMRead(ctx, d, m)
 }
 
+// minimum TrueNAS SCALE version (major, minor) for VM attributes not supported by bhyve on CORE
+var vmScaleAttributes = map[string][2]int{
+	"arch_type":             {22, 2},
+	"cpu_mode":              {22, 2},
+	"cpu_model":             {22, 2},
+	"ensure_display_device": {22, 2},
+	"hide_from_msr":         {22, 2},
+	"machine_type":          {22, 2},
+	"cpuset":                {22, 12},
+	"min_memory":            {22, 12},
+	"nodeset":               {22, 12},
+	"hyperv_enlightenments": {23, 10},
+}
+
+var cpusetRegexp = regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+
+func resourceTrueNASVMCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if cpuModel := d.Get("cpu_model").(string); cpuModel != "" {
+		if cpuMode := d.Get("cpu_mode").(string); cpuMode != "" && cpuMode != "CUSTOM" {
+			return fmt.Errorf("cpu_model can only be set when cpu_mode is CUSTOM, got %s", cpuMode)
+		}
+	}
+
+	// unknown values read as zero
+	if d.NewValueKnown("min_memory") && d.NewValueKnown("memory") {
+		if minMemory := d.Get("min_memory").(int); minMemory != 0 && minMemory >= d.Get("memory").(int) {
+			return fmt.Errorf("min_memory must be less than memory")
+		}
+	}
+
+	config := d.GetRawConfig()
+
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	var configured []string
+
+	for attr := range vmScaleAttributes {
+		if !config.GetAttr(attr).IsNull() {
+			configured = append(configured, attr)
+		}
+	}
+
+	if len(configured) == 0 {
+		return nil
+	}
+
+	version, err := getSystemVersion(ctx, m)
+
+	if err != nil {
+		return err
+	}
+
+	return checkVMScaleAttributes(version, configured)
+}
+
+// checkVMScaleAttributes returns error for first configured attribute not supported by version
+func checkVMScaleAttributes(version *systemVersion, configured []string) error {
+	sort.Strings(configured)
+
+	for _, attr := range configured {
+		minVersion := vmScaleAttributes[attr]
+
+		if !version.ScaleAtLeast(minVersion[0], minVersion[1]) {
+			return fmt.Errorf("%s requires TrueNAS SCALE %d.%02d or newer, got %s", attr, minVersion[0], minVersion[1], version.Raw)
+		}
+	}
+
+	return nil
+}
+
+func flattenVMAdditionalProperties(props map[string]interface{}) map[string]interface{} {
+	res := map[string]interface{}{}
+
+	for _, key := range []string{"cpu_mode", "arch_type", "machine_type"} {
+		if value, ok := props[key].(string); ok {
+			res[key] = value
+		}
+	}
+
+	// nullable attributes
+	for _, key := range []string{"cpu_model", "cpuset", "nodeset"} {
+		if _, ok := props[key]; ok {
+			value, _ := props[key].(string)
+			res[key] = value
+		}
+	}
+
+	if _, ok := props["min_memory"]; ok {
+		minMemory, _ := props["min_memory"].(float64)
+		res["min_memory"] = int(minMemory)
+	}
+
+	for _, key := range []string{"hide_from_msr", "ensure_display_device", "hyperv_enlightenments"} {
+		if value, ok := props[key].(bool); ok {
+			res[key] = value
+		}
+	}
+
+	return res
+}
+
+// expandVMAdditionalProperties returns VM attributes not covered by SDK params,
+// when update is true only changed attributes are returned, empty values reset attribute to null
+func expandVMAdditionalProperties(d *schema.ResourceData, update bool) map[string]interface{} {
+	props := map[string]interface{}{}
+
+	for _, key := range []string{"min_memory", "cpuset", "nodeset"} {
+		if update && !d.HasChange(key) {
+			continue
+		}
+
+		value, ok := d.GetOk(key)
+
+		if ok {
+			props[key] = value
+		} else if update {
+			props[key] = nil
+		}
+	}
+
+	if update {
+		if d.HasChange("hyperv_enlightenments") {
+			props["hyperv_enlightenments"] = d.Get("hyperv_enlightenments").(bool)
+		}
+	} else if hypervEnlightenments, ok := d.GetOkExists("hyperv_enlightenments"); ok {
+		props["hyperv_enlightenments"] = hypervEnlightenments.(bool)
+	}
+
+	return props
+}
+
 // TrueNAS api requires vm attribute set on updates even if it is new device
 // while that attribute cannot be set during creation (bug?)
 func expandVMDeviceForUpdate(d []interface{}, vmID *int32) ([]api.VMDevice, error) {
@@ -0,0 +1,107 @@
+package truenas
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckVMScaleAttributes(t *testing.T) {
+	core, _ := parseSystemVersion("TrueNAS-13.0-U6.1")
+	bluefin, _ := parseSystemVersion("TrueNAS-SCALE-22.12.4")
+	fangtooth, _ := parseSystemVersion("TrueNAS-25.04.0")
+
+	assert.NoError(t, checkVMScaleAttributes(core, nil))
+	assert.EqualError(t, checkVMScaleAttributes(core, []string{"min_memory", "cpu_mode"}), "cpu_mode requires TrueNAS SCALE 22.02 or newer, got TrueNAS-13.0-U6.1")
+
+	assert.NoError(t, checkVMScaleAttributes(bluefin, []string{"cpuset", "min_memory"}))
+	assert.EqualError(t, checkVMScaleAttributes(bluefin, []string{"hyperv_enlightenments"}), "hyperv_enlightenments requires TrueNAS SCALE 23.10 or newer, got TrueNAS-SCALE-22.12.4")
+
+	assert.NoError(t, checkVMScaleAttributes(fangtooth, []string{"hyperv_enlightenments", "cpuset"}))
+}
+
+func TestResourceTrueNASVMCustomizeDiff(t *testing.T) {
+	r := resourceTrueNASVM()
+	ctx := context.Background()
+
+	diff := func(raw map[string]interface{}) error {
+		raw["name"] = "test"
+		_, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), nil)
+		return err
+	}
+
+	assert.NoError(t, diff(map[string]interface{}{"cpu_mode": "CUSTOM", "cpu_model": "EPYC"}))
+	assert.EqualError(t, diff(map[string]interface{}{"cpu_mode": "HOST-PASSTHROUGH", "cpu_model": "EPYC"}), "cpu_model can only be set when cpu_mode is CUSTOM, got HOST-PASSTHROUGH")
+
+	assert.NoError(t, diff(map[string]interface{}{"memory": 1024 * 1024 * 1024, "min_memory": 512 * 1024 * 1024}))
+	assert.EqualError(t, diff(map[string]interface{}{"memory": 512 * 1024 * 1024, "min_memory": 512 * 1024 * 1024}), "min_memory must be less than memory")
+}
+
+func TestExpandVMAdditionalProperties(t *testing.T) {
+	r := resourceTrueNASVM()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                  "test",
+		"memory":                1024 * 1024 * 1024,
+		"min_memory":            512 * 1024 * 1024,
+		"cpuset":                "0-3",
+		"hyperv_enlightenments": false,
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"min_memory":            512 * 1024 * 1024,
+		"cpuset":                "0-3",
+		"hyperv_enlightenments": false,
+	}, expandVMAdditionalProperties(d, false))
+
+	// update sends changed attributes only, removed attributes are reset to null
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":                  "test",
+			"memory":                "1073741824",
+			"min_memory":            "536870912",
+			"cpuset":                "0-3",
+			"nodeset":               "0",
+			"hyperv_enlightenments": "false",
+		},
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                  "test",
+		"memory":                1024 * 1024 * 1024,
+		"min_memory":            512 * 1024 * 1024,
+		"nodeset":               "0",
+		"hyperv_enlightenments": true,
+	}), nil)
+	assert.NoError(t, err)
+
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cpuset":                nil,
+		"hyperv_enlightenments": true,
+	}, expandVMAdditionalProperties(d, true))
+}
+
+func TestFlattenVMAdditionalProperties(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"cpu_mode":              "CUSTOM",
+		"cpu_model":             "",
+		"cpuset":                "0-3",
+		"min_memory":            536870912,
+		"hyperv_enlightenments": true,
+	}, flattenVMAdditionalProperties(map[string]interface{}{
+		"cpu_mode":              "CUSTOM",
+		"cpu_model":             nil,
+		"cpuset":                "0-3",
+		"min_memory":            float64(536870912),
+		"hyperv_enlightenments": true,
+	}))
+
+	// CORE does not return SCALE only attributes
+	assert.Equal(t, map[string]interface{}{}, flattenVMAdditionalProperties(map[string]interface{}{"bootloader": "UEFI"}))
+}
@@ -154,7 +154,7 @@ func resourceTrueNASZVOL() *schema.Resource {
 func resourceTrueNASZVOLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id := d.Id()
 
 	resp, _, err := c.DatasetApi.GetDataset(ctx, id).Execute()
@@ -278,7 +278,7 @@ func resourceTrueNASZVOLRead(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceTrueNASZVOLCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	input := expandZvol(d)
 
@@ -300,7 +300,7 @@ func resourceTrueNASZVOLCreate(ctx context.Context, d *schema.ResourceData, m in
 func resourceTrueNASZVOLDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 	id := d.Id()
 
 	log.Printf("[DEBUG] Deleting TrueNAS zvol: %s", id)
@@ -322,7 +322,7 @@ func resourceTrueNASZVOLDelete(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceTrueNASZVOLUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*api.APIClient)
+	c := m.(*providerMeta)
 
 	input := api.UpdateDatasetParams{}
 
@@ -0,0 +1,72 @@
+package truenas
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// systemVersion is parsed TrueNAS version string, eg. TrueNAS-SCALE-22.12.0, TrueNAS-25.04.0 or TrueNAS-13.0-U3.1
+type systemVersion struct {
+	Raw   string
+	Scale bool
+	Major int
+	Minor int
+}
+
+// scaleMinMajor is the first SCALE major version, SCALE 25.04 and newer report versions without SCALE- prefix
+const scaleMinMajor = 20
+
+var systemVersionRegexp = regexp.MustCompile(`^TrueNAS-(SCALE-)?(\d+)\.(\d+)`)
+
+func parseSystemVersion(v string) (*systemVersion, error) {
+	matches := systemVersionRegexp.FindStringSubmatch(v)
+
+	if matches == nil {
+		return nil, fmt.Errorf("unsupported TrueNAS version: %s", v)
+	}
+
+	major, err := strconv.Atoi(matches[2])
+
+	if err != nil {
+		return nil, err
+	}
+
+	minor, err := strconv.Atoi(matches[3])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &systemVersion{
+		Raw:   v,
+		Scale: matches[1] != "" || major >= scaleMinMajor,
+		Major: major,
+		Minor: minor,
+	}, nil
+}
+
+// AtLeast returns true if version is equal or newer than major.minor
+func (v *systemVersion) AtLeast(major int, minor int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+
+	return v.Minor >= minor
+}
+
+// ScaleAtLeast returns true if system is TrueNAS SCALE equal or newer than major.minor
+func (v *systemVersion) ScaleAtLeast(major int, minor int) bool {
+	return v.Scale && v.AtLeast(major, minor)
+}
+
+func getSystemVersion(ctx context.Context, m interface{}) (*systemVersion, error) {
+	var version string
+
+	if err := apiGet(ctx, m, "/system/version", nil, &version); err != nil {
+		return nil, fmt.Errorf("error getting system version: %s", err)
+	}
+
+	return parseSystemVersion(version)
+}
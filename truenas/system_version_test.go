@@ -0,0 +1,39 @@
+package truenas
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseSystemVersion(t *testing.T) {
+	v, err := parseSystemVersion("TrueNAS-SCALE-22.12.0")
+	assert.NoError(t, err)
+	assert.True(t, v.Scale)
+	assert.Equal(t, 22, v.Major)
+	assert.Equal(t, 12, v.Minor)
+	assert.True(t, v.ScaleAtLeast(22, 12))
+	assert.False(t, v.ScaleAtLeast(23, 10))
+
+	v, err = parseSystemVersion("TrueNAS-25.04.0")
+	assert.NoError(t, err)
+	assert.True(t, v.Scale)
+	assert.Equal(t, 25, v.Major)
+	assert.Equal(t, 4, v.Minor)
+	assert.True(t, v.ScaleAtLeast(25, 4))
+	assert.True(t, v.ScaleAtLeast(24, 10))
+	assert.False(t, v.ScaleAtLeast(25, 10))
+
+	v, err = parseSystemVersion("TrueNAS-25.10.1")
+	assert.NoError(t, err)
+	assert.True(t, v.Scale)
+	assert.True(t, v.ScaleAtLeast(25, 10))
+
+	v, err = parseSystemVersion("TrueNAS-13.0-U3.1")
+	assert.NoError(t, err)
+	assert.False(t, v.Scale)
+	assert.True(t, v.AtLeast(12, 0))
+	assert.False(t, v.ScaleAtLeast(12, 0))
+
+	_, err = parseSystemVersion("FreeNAS-11.3-U5")
+	assert.Error(t, err)
+}